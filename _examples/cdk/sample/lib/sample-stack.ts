@@ -54,6 +54,7 @@ export class Function extends Construct {
       environment: {
         FILTER: "retention == infinite",
         DESIRED_STATE: "3months",
+        MAX_CHANGES: "100",
       },
     });
     this.alias = new cdk.aws_lambda.Alias(this, "Alias", {
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/nekrassov01/llcm"
 )
 
 var (
-	client  *llcm.Client
-	filter  string
-	desired string
+	client          *llcm.Client
+	filter          string
+	desired         string
+	dryRun          bool
+	maxChanges      int64
+	maxDeletedBytes int64
 )
 
 func init() {
@@ -29,6 +33,29 @@ func init() {
 	}
 	desired = d
 
+	// the safety rails are optional and disabled when not set
+	if v := os.Getenv("DRY_RUN"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			log.Fatal(err)
+		}
+		dryRun = b
+	}
+	if v := os.Getenv("MAX_CHANGES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+		maxChanges = n
+	}
+	if v := os.Getenv("MAX_DELETED_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatal(err)
+		}
+		maxDeletedBytes = n
+	}
+
 	cfg, err := llcm.LoadConfig(context.Background(), "")
 	if err != nil {
 		log.Fatal(err)
@@ -43,11 +70,9 @@ func handleRequest(ctx context.Context) error {
 	log.Println("handleRequest started")
 	w := os.Stdout
 
-	// initialize the manager
-	man := llcm.NewManager(client)
-
-	// set filter to the manager
-	if err := man.SetFilter(filter); err != nil {
+	// initialize the manager with filter
+	man, err := llcm.NewManager(client, llcm.WithFilter(filter))
+	if err != nil {
 		return err
 	}
 
@@ -56,12 +81,32 @@ func handleRequest(ctx context.Context) error {
 		return err
 	}
 
+	// set limits to abort before anything is changed
+	if err := man.SetMaxChanges(maxChanges); err != nil {
+		return err
+	}
+	if err := man.SetMaxDeletedBytes(maxDeletedBytes); err != nil {
+		return err
+	}
+
+	// go through apply without any write call if dry run
+	man.SetDryRun(dryRun)
+
+	// no one can answer the confirmation in lambda
+	man.SetAssumeYes(true)
+
 	// run apply operation
-	n, err := man.Apply(ctx, w)
+	data, err := man.Apply(ctx)
 	if err != nil {
 		return err
 	}
-	fmt.Fprintf(w, "done: %d\n", n)
+
+	// render the result for each log group as JSON
+	if err := llcm.NewRenderer(w, data).Render(); err != nil {
+		return err
+	}
+	total := data.Total()
+	fmt.Fprintf(w, "done: %d\n", total[llcm.TotalAppliedLabel])
 
 	log.Println("handleRequest finished")
 	return nil
@@ -2,7 +2,6 @@ package llcm
 
 import (
 	"context"
-	"io"
 	"testing"
 	"time"
 
@@ -57,7 +56,8 @@ func prepare(n int, regions []string) *Manager {
 		}),
 		regions:      regions,
 		desiredState: 365,
-		sem:          semaphore.NewWeighted(NumWorker),
+		sem:          semaphore.NewWeighted(defaultConcurrency),
+		clock:        nowFunc,
 	}
 }
 
@@ -84,7 +84,7 @@ func BenchmarkPreview(b *testing.B) {
 func BenchmarkApply(b *testing.B) {
 	man := prepare(benchN, benchR)
 	for b.Loop() {
-		_, err := man.Apply(context.Background(), io.Discard)
+		_, err := man.Apply(context.Background())
 		if err != nil {
 			b.Fatal(err)
 		}
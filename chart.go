@@ -20,6 +20,8 @@ var (
 	BaseName = "llcm"
 
 	// MaxPieChartItems is the maximum number of items in a pie chart.
+	//
+	// Deprecated: Use WithMaxPieChartItems instead. It no longer affects the behavior.
 	MaxPieChartItems = 11
 
 	// MaxBarChartItems is the maximum number of items in a bar chart.
@@ -32,6 +34,9 @@ var (
 	BarChartTitle = "The simulation of reductions in log groups"
 )
 
+// defaultMaxPieChartItems is the default maximum number of items in a pie chart.
+const defaultMaxPieChartItems = 11
+
 func render(chart components.Charter) error {
 	var (
 		fname = fmt.Sprintf("%s.html", BaseName)
@@ -61,13 +66,36 @@ func render(chart components.Charter) error {
 	return nil
 }
 
-func getPieItems[E Entry](entries []E) []opts.PieData {
+// chartNames returns the names of the entries for the chart items.
+// The names are prefixed with the account ID when the entries span multiple accounts,
+// since the same log group name can exist in each account.
+func chartNames[E Entry](entries []E) []string {
+	names := make([]string, len(entries))
+	accounts := make(map[string]struct{})
+	for i, entry := range entries {
+		names[i] = entry.Name()
+		accounts[entry.account()] = struct{}{}
+	}
+	if len(accounts) < 2 {
+		return names
+	}
+	for i, entry := range entries {
+		names[i] = entry.account() + ":" + names[i]
+	}
+	return names
+}
+
+func getPieItems[E Entry](entries []E, maxItems int) []opts.PieData {
 	if len(entries) == 0 {
 		return nil
 	}
+	if maxItems <= 0 {
+		maxItems = defaultMaxPieChartItems
+	}
 	var (
 		othersTotal int64
-		items       = make([]opts.PieData, 0, MaxPieChartItems)
+		names       = chartNames(entries)
+		items       = make([]opts.PieData, 0, maxItems)
 	)
 	for i, entry := range entries {
 		m := entry.DataSet()
@@ -75,9 +103,9 @@ func getPieItems[E Entry](entries []E) []opts.PieData {
 		if v == 0 {
 			continue
 		}
-		if i < MaxPieChartItems-1 {
+		if i < maxItems-1 {
 			item := opts.PieData{
-				Name:  entry.Name(),
+				Name:  names[i],
 				Value: v,
 			}
 			items = append(items, item)
@@ -163,6 +191,7 @@ func getBarItems[E Entry](entries []E) ([]string, []opts.BarData, []opts.BarData
 	var (
 		rmOthersTotal int64
 		rdOthersTotal int64
+		names         = chartNames(entries)
 		lnames        = make([]string, 0, MaxBarChartItems)
 		rmbytes       = make([]opts.BarData, 0, MaxBarChartItems)
 		rdbytes       = make([]opts.BarData, 0, MaxBarChartItems)
@@ -177,7 +206,7 @@ func getBarItems[E Entry](entries []E) ([]string, []opts.BarData, []opts.BarData
 			continue
 		}
 		if i < MaxBarChartItems-1 {
-			lnames = append(lnames, entry.Name())
+			lnames = append(lnames, names[i])
 			rmbytes = append(rmbytes, opts.BarData{Value: rmb})
 			rdbytes = append(rdbytes, opts.BarData{Value: rdb})
 		} else {
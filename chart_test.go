@@ -253,10 +253,53 @@ func Test_getPieItems(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "multiple accounts",
+			args: args{
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "group0",
+							AccountID:       "123456789012",
+							Region:          "ap-northeast-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       time.Now(),
+							ElapsedDays:     0,
+							RetentionInDays: 30,
+							StoredBytes:     1024,
+						},
+					},
+					{
+						entry: &entry{
+							LogGroupName:    "group0",
+							AccountID:       "210987654321",
+							Region:          "ap-northeast-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       time.Now(),
+							ElapsedDays:     0,
+							RetentionInDays: 30,
+							StoredBytes:     4096,
+						},
+					},
+				},
+			},
+			want: want{
+				items: []opts.PieData{
+					{
+						Name:  "123456789012:group0",
+						Value: int64(1024),
+					},
+					{
+						Name:  "210987654321:group0",
+						Value: int64(4096),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			items := getPieItems(tt.args.entries)
+			items := getPieItems(tt.args.entries, 3)
 			if !reflect.DeepEqual(items, tt.want.items) {
 				t.Errorf("getPieItems() items = %v, want %v", items, tt.want.items)
 			}
@@ -2,9 +2,14 @@ package llcm
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 )
 
 var _ API = (*Client)(nil)
@@ -16,16 +21,68 @@ type API interface {
 	DeleteRetentionPolicy(ctx context.Context, params *cloudwatchlogs.DeleteRetentionPolicyInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error)
 	DeleteLogGroup(ctx context.Context, params *cloudwatchlogs.DeleteLogGroupInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error)
 	PutLogGroupDeletionProtection(ctx context.Context, params *cloudwatchlogs.PutLogGroupDeletionProtectionInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogGroupDeletionProtectionOutput, error)
+	ListTagsForResource(ctx context.Context, params *cloudwatchlogs.ListTagsForResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error)
+	DescribeLogStreams(ctx context.Context, params *cloudwatchlogs.DescribeLogStreamsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
+	TagResource(ctx context.Context, params *cloudwatchlogs.TagResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error)
 }
 
 // Client represents a client for CloudWatch Logs.
 type Client struct {
 	API
+
+	accountID  string      // The account ID of the assumed role, empty for the default credentials.
+	cfg        *aws.Config // The config to create the clients to check the owners of the log groups.
+	ownersOnce sync.Once   // Creates the clients to check the owners only once.
+	owners     *Owners     // The clients to check the owners of the log groups.
 }
 
 // NewClient creates a new client.
 func NewClient(cfg aws.Config) *Client {
 	return &Client{
-		cloudwatchlogs.NewFromConfig(cfg),
+		API: cloudwatchlogs.NewFromConfig(cfg),
+		cfg: &cfg,
+	}
+}
+
+// NewAssumeRoleClient creates a new client that assumes the specified role.
+// The account ID of the client is resolved from the role ARN.
+func NewAssumeRoleClient(cfg aws.Config, roleARN string) (*Client, error) {
+	a, err := arn.Parse(roleARN)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role arn: %q: %w", roleARN, err)
+	}
+	if a.Service != "iam" || a.AccountID == "" {
+		return nil, fmt.Errorf("invalid role arn: %q", roleARN)
 	}
+	cfg = cfg.Copy()
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN)
+	cfg.Credentials = aws.NewCredentialsCache(provider)
+	return &Client{
+		API:       cloudwatchlogs.NewFromConfig(cfg),
+		accountID: a.AccountID,
+		cfg:       &cfg,
+	}, nil
+}
+
+// SetOwners sets the clients to check the owners of the log groups before the first check.
+// Otherwise, they are created from the config of the client only when the owners are checked.
+// Without both of them, no log group is taken as orphaned.
+func (c *Client) SetOwners(owners *Owners) {
+	c.owners = owners
+}
+
+// ownerClients returns the clients to check the owners of the log groups, creating them on the first call.
+func (c *Client) ownerClients() *Owners {
+	c.ownersOnce.Do(func() {
+		if c.owners == nil && c.cfg != nil {
+			c.owners = NewOwners(*c.cfg)
+		}
+	})
+	return c.owners
+}
+
+// AccountID returns the account ID of the client.
+// It returns an empty string if the client uses the default credentials.
+func (c *Client) AccountID() string {
+	return c.accountID
 }
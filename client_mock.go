@@ -15,6 +15,9 @@ type mockClient struct {
 	DeleteRetentionPolicyFunc         func(ctx context.Context, params *cloudwatchlogs.DeleteRetentionPolicyInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error)
 	DeleteLogGroupFunc                func(ctx context.Context, params *cloudwatchlogs.DeleteLogGroupInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error)
 	PutLogGroupDeletionProtectionFunc func(ctx context.Context, params *cloudwatchlogs.PutLogGroupDeletionProtectionInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogGroupDeletionProtectionOutput, error)
+	ListTagsForResourceFunc           func(ctx context.Context, params *cloudwatchlogs.ListTagsForResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error)
+	DescribeLogStreamsFunc            func(ctx context.Context, params *cloudwatchlogs.DescribeLogStreamsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
+	TagResourceFunc                   func(ctx context.Context, params *cloudwatchlogs.TagResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error)
 }
 
 // DescribeLogGroups describes the specified log groups.
@@ -42,9 +45,24 @@ func (m *mockClient) PutLogGroupDeletionProtection(ctx context.Context, params *
 	return m.PutLogGroupDeletionProtectionFunc(ctx, params, optFns...)
 }
 
+// ListTagsForResource lists the tags of the specified resource.
+func (m *mockClient) ListTagsForResource(ctx context.Context, params *cloudwatchlogs.ListTagsForResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+	return m.ListTagsForResourceFunc(ctx, params, optFns...)
+}
+
+// DescribeLogStreams describes the log streams of the specified log group.
+func (m *mockClient) DescribeLogStreams(ctx context.Context, params *cloudwatchlogs.DescribeLogStreamsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return m.DescribeLogStreamsFunc(ctx, params, optFns...)
+}
+
+// TagResource tags the specified resource.
+func (m *mockClient) TagResource(ctx context.Context, params *cloudwatchlogs.TagResourceInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error) {
+	return m.TagResourceFunc(ctx, params, optFns...)
+}
+
 // newMockClient creates a new mock client.
 func newMockClient(api API) *Client {
 	return &Client{
-		api,
+		API: api,
 	}
 }
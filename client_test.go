@@ -0,0 +1,106 @@
+package llcm
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestNewAssumeRoleClient(t *testing.T) {
+	type args struct {
+		cfg     aws.Config
+		roleARN string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid role arn",
+			args: args{
+				cfg:     aws.Config{Region: "us-east-1"},
+				roleARN: "arn:aws:iam::123456789012:role/llcm",
+			},
+			want:    "123456789012",
+			wantErr: false,
+		},
+		{
+			name: "invalid arn",
+			args: args{
+				cfg:     aws.Config{Region: "us-east-1"},
+				roleARN: "invalid",
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "not a role arn",
+			args: args{
+				cfg:     aws.Config{Region: "us-east-1"},
+				roleARN: "arn:aws:logs:us-east-1:123456789012:log-group:test",
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAssumeRoleClient(tt.args.cfg, tt.args.roleARN)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAssumeRoleClient() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil && got.AccountID() != tt.want {
+				t.Errorf("NewAssumeRoleClient() = %v, want %v", got.AccountID(), tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ownerClients(t *testing.T) {
+	tests := []struct {
+		name   string
+		client *Client
+		set    *Owners
+		want   bool
+	}{
+		{
+			name:   "created from config",
+			client: NewClient(aws.Config{Region: "us-east-1"}),
+			want:   true,
+		},
+		{
+			name:   "set",
+			client: newMockClient(&mockClient{}),
+			set:    (&fakeOwners{}).owners(),
+			want:   true,
+		},
+		{
+			name:   "without config",
+			client: newMockClient(&mockClient{}),
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.client.owners != nil {
+				t.Fatalf("Client.owners is created before the first check")
+			}
+			if tt.set != nil {
+				tt.client.SetOwners(tt.set)
+			}
+			got := tt.client.ownerClients()
+			if (got != nil) != tt.want {
+				t.Errorf("Client.ownerClients() = %v, want %v", got, tt.want)
+			}
+			if tt.set != nil && got != tt.set {
+				t.Errorf("Client.ownerClients() does not return the owners set")
+			}
+			if got != tt.client.ownerClients() {
+				t.Errorf("Client.ownerClients() is not reused")
+			}
+		})
+	}
+}
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"iter"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/dustin/go-humanize"
@@ -18,6 +26,69 @@ const (
 	label = "LLCM"
 )
 
+const (
+	// exitCodeError is the exit code when the process failed.
+	exitCodeError = 1
+
+	// exitCodePartialFailure is the exit code when the process finished but some regions or log groups failed.
+	exitCodePartialFailure = 2
+)
+
+// exitCode returns the exit code for the error.
+func exitCode(err error) int {
+	var errs llcm.Errors
+	if errors.As(err, &errs) {
+		return exitCodePartialFailure
+	}
+	return exitCodeError
+}
+
+// streamJSONL writes the entries yielded by the iterator as JSON Lines as they are found,
+// and passes each entry to the function. The error yielded at last is returned.
+func streamJSONL[E any](w io.Writer, seq iter.Seq2[E, error], fn func(E)) error {
+	enc := json.NewEncoder(w)
+	for e, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+		fn(e)
+	}
+	return nil
+}
+
+// splitTagIssues splits the TagIssues off the error yielded at last by the preview,
+// and returns them with the rest of the error, which is nil if the error is nothing but the TagIssues.
+func splitTagIssues(err error) (llcm.TagIssues, error) {
+	if err == nil {
+		return nil, nil
+	}
+	if issues, ok := err.(llcm.TagIssues); ok {
+		return issues, nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if _, isErrs := err.(llcm.Errors); !ok || isErrs {
+		return nil, err
+	}
+	var (
+		issues llcm.TagIssues
+		errs   []error
+	)
+	for _, e := range joined.Unwrap() {
+		if t, ok := e.(llcm.TagIssues); ok {
+			issues = append(issues, t...)
+			continue
+		}
+		errs = append(errs, e)
+	}
+	if len(errs) == 1 {
+		return issues, errs[0]
+	}
+	return issues, errors.Join(errs...)
+}
+
 var logger = &log.Logger{}
 
 func newCmd(w, ew io.Writer) *cli.Command {
@@ -46,19 +117,160 @@ func newCmd(w, ew io.Writer) *cli.Command {
 		DefaultText: "all regions with no opt-in",
 	}
 
+	roleARN := &cli.StringSliceFlag{
+		Name:    "role-arn",
+		Aliases: []string{"R"},
+		Usage:   "set role arns to assume for each target account",
+	}
+
+	accountsFile := &cli.StringFlag{
+		Name:    "accounts-file",
+		Aliases: []string{"a"},
+		Usage:   "set the path to a file listing role arns for each target account",
+	}
+
 	filter := &cli.StringFlag{
 		Name:    "filter",
 		Aliases: []string{"f"},
 		Usage:   "set expressions to filter log groups",
 	}
 
+	resultFilter := &cli.StringFlag{
+		Name:  "result-filter",
+		Usage: "set expressions to filter log groups by the simulated results of the desired state",
+	}
+
+	withTags := &cli.BoolFlag{
+		Name:  "with-tags",
+		Usage: "fetch the tags of log groups",
+	}
+
+	withIdle := &cli.BoolFlag{
+		Name:  "with-idle",
+		Usage: "fetch the last event of log groups to render LastEventAt and IdleDays",
+	}
+
+	withOrphan := &cli.BoolFlag{
+		Name:  "with-orphan",
+		Usage: "check whether the resources that own log groups still exist to render Orphaned",
+	}
+
+	tagColumns := &cli.StringSliceFlag{
+		Name:  "tag-columns",
+		Usage: "set tag keys to render as extra columns",
+	}
+
 	desired := &cli.StringFlag{
-		Name:     "desired",
-		Aliases:  []string{"d"},
-		Usage:    "set the desired state",
+		Name:    "desired",
+		Aliases: []string{"d"},
+		Usage:   "set the desired state by name, day count, ISO-8601 duration or Go duration, or from-tag[:key] to read it from the tag of each log group",
+	}
+
+	defaultState := &cli.StringFlag{
+		Name:  "default",
+		Usage: "set the desired state used when the tag of the log group does not declare it with --desired from-tag",
+	}
+
+	policy := &cli.StringFlag{
+		Name:    "policy",
+		Aliases: []string{"P"},
+		Usage:   "set the path to a policy file with ordered rules of filter and desired state",
+	}
+
+	round := &cli.StringFlag{
+		Name:  "round",
+		Usage: "set the direction to snap the desired retention that is not allowed to the nearest allowed one: none, up or down",
+		Value: llcm.RoundNone.String(),
+	}
+
+	mode := &cli.StringFlag{
+		Name:    "mode",
+		Aliases: []string{"m"},
+		Usage:   "set the direction in which the retention is allowed to change: exact, shorten-only or lengthen-only",
+		Value:   llcm.ModeExact.String(),
+	}
+
+	forceUnprotect := &cli.BoolFlag{
+		Name:  "force-unprotect",
+		Usage: "disable deletion protection to delete protected log groups instead of skipping them",
+	}
+
+	out := &cli.StringFlag{
+		Name:  "out",
+		Usage: "set the path to save the preview as a plan for apply",
+	}
+
+	plan := &cli.StringFlag{
+		Name:  "plan",
+		Usage: "set the path to a plan saved by preview to apply exactly",
+	}
+
+	allowDrift := &cli.BoolFlag{
+		Name:  "allow-drift",
+		Usage: "skip log groups that drifted from the plan instead of failing",
+	}
+
+	journal := &cli.StringFlag{
+		Name:    "journal",
+		Aliases: []string{"j"},
+		Usage:   "set the path to a journal to append the prior state before each change",
+	}
+
+	rollbackJournal := &cli.StringFlag{
+		Name:     "journal",
+		Aliases:  []string{"j"},
+		Usage:    "set the path to a journal written by apply to restore the prior state",
 		Required: true,
 	}
 
+	dryRun := &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "go through apply without any write call",
+	}
+
+	tagApplied := &cli.BoolFlag{
+		Name:  "tag-applied",
+		Usage: "tag the changed log groups with llcm:managed-by, llcm:applied-at and llcm:desired-state",
+	}
+
+	maxChanges := &cli.Int64Flag{
+		Name:        "max-changes",
+		Usage:       "abort apply when more log groups than this would be changed",
+		DefaultText: "no limit",
+	}
+
+	maxDeletedBytes := &cli.Int64Flag{
+		Name:        "max-deleted-bytes",
+		Usage:       "abort apply when log groups storing more bytes than this would be deleted",
+		DefaultText: "no limit",
+	}
+
+	yes := &cli.BoolFlag{
+		Name:    "yes",
+		Aliases: []string{"y"},
+		Usage:   "apply without confirmation",
+	}
+
+	continueOnError := &cli.BoolFlag{
+		Name:  "continue-on-error",
+		Usage: "collect errors per region and log group and finish the remaining work",
+	}
+
+	sortBy := &cli.StringFlag{
+		Name:    "sort",
+		Aliases: []string{"s"},
+		Usage:   "set the key to sort entries: bytes or action",
+		Value:   "bytes",
+		Validator: func(s string) error {
+			switch s {
+			case "bytes", "action":
+				return nil
+			default:
+				return fmt.Errorf("unsupported sort key: %q", s)
+			}
+		},
+	}
+
 	output := &cli.StringFlag{
 		Name:    "output",
 		Aliases: []string{"o"},
@@ -71,6 +283,152 @@ func newCmd(w, ew io.Writer) *cli.Command {
 		logger.Debug("ManagerState: " + man.String())
 	}
 
+	accountTotal := func(totals map[string]map[string]int64) {
+		if len(totals) < 2 {
+			return
+		}
+		for _, account := range slices.Sorted(maps.Keys(totals)) {
+			total := totals[account]
+			args := make([]any, 0, len(total)*2+2)
+			args = append(args, "account", account)
+			for _, k := range slices.Sorted(maps.Keys(total)) {
+				args = append(args, k, humanize.Comma(total[k]))
+			}
+			logger.Info("total", args...)
+		}
+	}
+
+	retryStats := func(man *llcm.Manager) {
+		stats := man.RetryStats()
+		if stats.Retries == 0 && stats.Throttles == 0 {
+			return
+		}
+		logger.Info("retried", "retries", stats.Retries, "throttles", stats.Throttles)
+	}
+
+	summarize := func(cmd *cli.Command, err error) error {
+		var errs llcm.Errors
+		if !errors.As(err, &errs) {
+			return err
+		}
+		ren := llcm.NewRenderer(ew, errs)
+		outputType := cmd.String(output.Name)
+		if outputType == llcm.OutputTypeChart.String() {
+			outputType = llcm.OutputTypeCompressedText.String()
+		}
+		if err := ren.SetOutputType(outputType); err != nil {
+			return err
+		}
+		if err := ren.Render(); err != nil {
+			return err
+		}
+		return err
+	}
+
+	tagIssues := func(cmd *cli.Command, issues llcm.TagIssues) error {
+		if len(issues) == 0 {
+			return nil
+		}
+		logger.Warn("tags not declaring the desired state", "count", len(issues))
+		ren := llcm.NewRenderer(ew, issues)
+		outputType := cmd.String(output.Name)
+		if outputType == llcm.OutputTypeChart.String() {
+			outputType = llcm.OutputTypeCompressedText.String()
+		}
+		if err := ren.SetOutputType(outputType); err != nil {
+			return err
+		}
+		return ren.Render()
+	}
+
+	tagErrors := func(data *llcm.ApplyEntryData) int {
+		n := 0
+		for _, e := range data.Entries() {
+			if e.TagError != "" {
+				n++
+			}
+		}
+		return n
+	}
+
+	setDesired := func(cmd *cli.Command, man *llcm.Manager) error {
+		if err := man.SetMode(cmd.String(mode.Name)); err != nil {
+			return err
+		}
+		man.SetForceUnprotect(cmd.Bool(forceUnprotect.Name))
+		if err := man.SetResultFilter(cmd.String(resultFilter.Name)); err != nil {
+			return err
+		}
+		d, p := cmd.String(desired.Name), cmd.String(policy.Name)
+		switch {
+		case d != "" && p != "":
+			return fmt.Errorf("cannot specify both --%s and --%s", desired.Name, policy.Name)
+		case p != "":
+			for _, name := range []string{round.Name, defaultState.Name} {
+				if cmd.IsSet(name) {
+					return fmt.Errorf("cannot specify --%s with --%s", name, policy.Name)
+				}
+			}
+			return man.SetPolicy(p)
+		case d != "":
+			if err := man.SetRound(cmd.String(round.Name)); err != nil {
+				return err
+			}
+			if err := man.SetDesiredState(d); err != nil {
+				return err
+			}
+			return man.SetDefaultState(cmd.String(defaultState.Name))
+		default:
+			return fmt.Errorf("either --%s or --%s is required", desired.Name, policy.Name)
+		}
+	}
+
+	setPlan := func(cmd *cli.Command, man *llcm.Manager) error {
+		for _, name := range []string{desired.Name, defaultState.Name, policy.Name, filter.Name, resultFilter.Name} {
+			if cmd.String(name) != "" {
+				return fmt.Errorf("cannot specify --%s with --%s", name, plan.Name)
+			}
+		}
+		for _, name := range []string{round.Name, mode.Name, forceUnprotect.Name} {
+			if cmd.IsSet(name) {
+				return fmt.Errorf("cannot specify --%s with --%s", name, plan.Name)
+			}
+		}
+		man.SetAllowDrift(cmd.Bool(allowDrift.Name))
+		return man.SetPlan(cmd.String(plan.Name))
+	}
+
+	confirm := func(cmd *cli.Command) llcm.ConfirmFunc {
+		return func(_ context.Context, s *llcm.ApplySummary) (bool, error) {
+			fmt.Fprintf(ew, "%d log groups (%s bytes stored) will be changed, including %d deletions (%s bytes stored).\nDo you want to continue? [y/N]: ",
+				s.Changes, humanize.Comma(s.StoredBytes), s.Deletes, humanize.Comma(s.DeletedBytes),
+			)
+			answer, err := bufio.NewReader(cmd.Root().Reader).ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				return false, err
+			}
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "y", "yes":
+				return true, nil
+			default:
+				return false, nil
+			}
+		}
+	}
+
+	setSafety := func(cmd *cli.Command, man *llcm.Manager) error {
+		if err := man.SetMaxChanges(cmd.Int64(maxChanges.Name)); err != nil {
+			return err
+		}
+		if err := man.SetMaxDeletedBytes(cmd.Int64(maxDeletedBytes.Name)); err != nil {
+			return err
+		}
+		man.SetDryRun(cmd.Bool(dryRun.Name))
+		man.SetAssumeYes(cmd.Bool(yes.Name))
+		man.SetConfirm(confirm(cmd))
+		return nil
+	}
+
 	newManager := func(cmd *cli.Command) (*llcm.Manager, error) {
 		// get aws config from the metadata
 		cfg := cmd.Metadata["config"].(aws.Config)
@@ -78,19 +436,54 @@ func newCmd(w, ew io.Writer) *cli.Command {
 		// create a new client
 		client := llcm.NewClient(cfg)
 
-		// initialize the manager
-		man := llcm.NewManager(client)
+		// initialize the manager with regions and filter
+		man, err := llcm.NewManager(client,
+			llcm.WithLogger(logger.Logger),
+			llcm.WithRegions(cmd.StringSlice(region.Name)...),
+			llcm.WithFilter(cmd.String(filter.Name)),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// collect role arns from the flag and the accounts file
+		roleARNs := cmd.StringSlice(roleARN.Name)
+		if path := cmd.String(accountsFile.Name); path != "" {
+			a, err := llcm.LoadRoleARNs(path)
+			if err != nil {
+				return nil, err
+			}
+			roleARNs = append(roleARNs, a...)
+		}
 
-		// set regions to the manager
-		if err := man.SetRegion(cmd.StringSlice(region.Name)); err != nil {
+		// create clients that assume the role into each account
+		clients := make([]*llcm.Client, 0, len(roleARNs))
+		for _, arn := range roleARNs {
+			c, err := llcm.NewAssumeRoleClient(cfg, arn)
+			if err != nil {
+				return nil, err
+			}
+			clients = append(clients, c)
+		}
+
+		// set accounts to the manager
+		if err := man.SetAccount(clients); err != nil {
 			return nil, err
 		}
 
-		// set filter to the manager
-		if err := man.SetFilter(cmd.String(filter.Name)); err != nil {
+		// set whether to continue on error to the manager
+		man.SetContinueOnError(cmd.Bool(continueOnError.Name))
+
+		// set whether to fetch tags and the tag columns to the manager
+		man.SetWithTags(cmd.Bool(withTags.Name))
+		if err := man.SetTagColumns(cmd.StringSlice(tagColumns.Name)); err != nil {
 			return nil, err
 		}
 
+		// set whether to fetch the last event and check the owner to the manager
+		man.SetWithIdle(cmd.Bool(withIdle.Name))
+		man.SetWithOrphan(cmd.Bool(withOrphan.Name))
+
 		return man, nil
 	}
 
@@ -149,9 +542,35 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			return err
 		}
 
+		// stream the result as JSON Lines as the log groups are found
+		if cmd.String(output.Name) == llcm.OutputTypeJSONL.String() {
+			var total int64
+			totals := make(map[string]map[string]int64)
+			err = streamJSONL(w, man.All(ctx), func(e *llcm.ListEntry) {
+				total += e.StoredBytes
+				if totals[e.AccountID] == nil {
+					totals[e.AccountID] = make(map[string]int64)
+				}
+				totals[e.AccountID][llcm.TotalStoredBytesLabel] += e.StoredBytes
+			})
+			var errs llcm.Errors
+			if err != nil && !errors.As(err, &errs) {
+				return err
+			}
+			debug(man)
+			accountTotal(totals)
+			retryStats(man)
+			logger.Info(
+				"stopped",
+				llcm.TotalStoredBytesLabel, humanize.Comma(total),
+			)
+			return summarize(cmd, err)
+		}
+
 		// run list operation
+		// data is returned together with the collected errors when continuing on error
 		data, err := man.List(ctx)
-		if err != nil {
+		if data == nil {
 			return err
 		}
 		debug(man)
@@ -172,6 +591,12 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			return err
 		}
 
+		// logging per-account totals when multiple accounts are found
+		accountTotal(data.TotalByAccount())
+
+		// logging the retries and throttled attempts of the api calls
+		retryStats(man)
+
 		// logging at process stop with total bytes
 		total := data.Total()
 		logger.Info(
@@ -179,10 +604,17 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			llcm.TotalStoredBytesLabel, humanize.Comma(total[llcm.TotalStoredBytesLabel]),
 		)
 
-		return nil
+		// render the error summary when continuing on error
+		return summarize(cmd, err)
 	}
 
 	preview := func(ctx context.Context, cmd *cli.Command) error {
+		// the result is streamed as JSON Lines unless saved as a plan, so it cannot be sorted
+		stream := cmd.String(output.Name) == llcm.OutputTypeJSONL.String() && cmd.String(out.Name) == ""
+		if stream && cmd.IsSet(sortBy.Name) {
+			return fmt.Errorf("cannot specify --%s with %s output", sortBy.Name, llcm.OutputTypeJSONL)
+		}
+
 		// logging at process start
 		logger.Info("started")
 
@@ -192,20 +624,64 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			return err
 		}
 
-		// set desired state to the manager
-		if err := man.SetDesiredState(cmd.String(desired.Name)); err != nil {
+		// set desired state or policy to the manager
+		if err := setDesired(cmd, man); err != nil {
 			return err
 		}
 
+		// stream the result as JSON Lines as the log groups are found
+		// the whole result is needed to save the plan, so it is not streamed in that case
+		if stream {
+			var storedBytes, reducibleBytes, remainingBytes int64
+			totals := make(map[string]map[string]int64)
+			err = streamJSONL(w, man.PreviewAll(ctx), func(e *llcm.PreviewEntry) {
+				storedBytes += e.StoredBytes
+				reducibleBytes += e.ReducibleBytes
+				remainingBytes += e.RemainingBytes
+				if totals[e.AccountID] == nil {
+					totals[e.AccountID] = make(map[string]int64)
+				}
+				totals[e.AccountID][llcm.TotalStoredBytesLabel] += e.StoredBytes
+				totals[e.AccountID][llcm.TotalReducibleBytesLabel] += e.ReducibleBytes
+				totals[e.AccountID][llcm.TotalRemainingBytesLabel] += e.RemainingBytes
+			})
+			var (
+				errs   llcm.Errors
+				issues llcm.TagIssues
+			)
+			issues, err = splitTagIssues(err)
+			if err != nil && !errors.As(err, &errs) {
+				return err
+			}
+			debug(man)
+			accountTotal(totals)
+			retryStats(man)
+			if err := tagIssues(cmd, issues); err != nil {
+				return err
+			}
+			logger.Info(
+				"stopped",
+				llcm.TotalStoredBytesLabel, humanize.Comma(storedBytes),
+				llcm.TotalReducibleBytesLabel, humanize.Comma(reducibleBytes),
+				llcm.TotalRemainingBytesLabel, humanize.Comma(remainingBytes),
+			)
+			return summarize(cmd, err)
+		}
+
 		// run preview operation
+		// data is returned together with the collected errors when continuing on error
 		data, err := man.Preview(ctx)
-		if err != nil {
+		if data == nil {
 			return err
 		}
 		debug(man)
 
-		// sort result
-		llcm.SortEntries(data)
+		// sort result by the specified key
+		if cmd.String(sortBy.Name) == "action" {
+			llcm.SortEntriesByAction(data)
+		} else {
+			llcm.SortEntries(data)
+		}
 
 		// create renderer with data
 		ren := llcm.NewRenderer(w, data)
@@ -220,6 +696,25 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			return err
 		}
 
+		// save the preview as a plan for apply
+		if path := cmd.String(out.Name); path != "" {
+			if err := llcm.NewPlan(data).Save(path); err != nil {
+				return err
+			}
+			logger.Info("plan saved", "path", path)
+		}
+
+		// logging per-account totals when multiple accounts are found
+		accountTotal(data.TotalByAccount())
+
+		// logging the retries and throttled attempts of the api calls
+		retryStats(man)
+
+		// render the log groups whose tags do not declare the desired state
+		if err := tagIssues(cmd, data.TagIssues()); err != nil {
+			return err
+		}
+
 		// logging at process stop with the total bytes information
 		total := data.Total()
 		logger.Info(
@@ -229,7 +724,8 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			llcm.TotalRemainingBytesLabel, humanize.Comma(total[llcm.TotalRemainingBytesLabel]),
 		)
 
-		return nil
+		// render the error summary when continuing on error
+		return summarize(cmd, err)
 	}
 
 	apply := func(ctx context.Context, cmd *cli.Command) error {
@@ -242,25 +738,151 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			return err
 		}
 
-		// set desired state to the manager
-		if err := man.SetDesiredState(cmd.String(desired.Name)); err != nil {
+		// set plan, desired state or policy to the manager
+		if cmd.String(plan.Name) != "" {
+			err = setPlan(cmd, man)
+		} else {
+			err = setDesired(cmd, man)
+		}
+		if err != nil {
 			return err
 		}
 
+		// set limits, dry run and confirmation to the manager
+		if err := setSafety(cmd, man); err != nil {
+			return err
+		}
+
+		// set journal to record the prior state before each change
+		if path := cmd.String(journal.Name); path != "" {
+			j, err := llcm.OpenJournal(path)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = j.Close()
+			}()
+			man.SetJournal(j)
+		}
+
+		// set whether to tag the changed log groups
+		man.SetTagApplied(cmd.Bool(tagApplied.Name))
+
 		// run apply operation
-		n, err := man.Apply(ctx, w)
+		// data is returned together with the errors to report the entries already processed
+		data, err := man.Apply(ctx)
+		if data == nil {
+			return err
+		}
+		debug(man)
+
+		// sort result by the specified key
+		if cmd.String(sortBy.Name) == "action" {
+			llcm.SortEntriesByAction(data)
+		} else {
+			llcm.SortEntries(data)
+		}
+
+		// create renderer with data
+		ren := llcm.NewRenderer(w, data)
+
+		// set output type passed as string
+		if err := ren.SetOutputType(cmd.String(output.Name)); err != nil {
+			return err
+		}
+
+		// render result
+		if err := ren.Render(); err != nil {
+			return err
+		}
+
+		// logging per-account totals when multiple accounts are found
+		accountTotal(data.TotalByAccount())
+
+		// logging the retries and throttled attempts of the api calls
+		retryStats(man)
+
+		// logging the log groups changed but failed to be tagged
+		if n := tagErrors(data); n > 0 {
+			logger.Warn("failed to tag", "count", n)
+		}
+
+		// render the log groups whose tags do not declare the desired state
+		if err := tagIssues(cmd, data.TagIssues()); err != nil {
+			return err
+		}
+
+		// logging at process stop with the number of applied, failed and skipped entries
+		total := data.Total()
+		logger.Info(
+			"stopped",
+			llcm.TotalAppliedLabel, total[llcm.TotalAppliedLabel],
+			llcm.TotalFailedLabel, total[llcm.TotalFailedLabel],
+			llcm.TotalSkippedLabel, total[llcm.TotalSkippedLabel],
+			"dryRun", cmd.Bool(dryRun.Name),
+		)
+
+		// render the error summary when continuing on error
+		return summarize(cmd, err)
+	}
+
+	rollback := func(ctx context.Context, cmd *cli.Command) error {
+		// logging at process start
+		logger.Info("started")
+
+		// create manager with common settings
+		man, err := newManager(cmd)
+		if err != nil {
+			return err
+		}
+
+		// load the prior state recorded by apply
+		entries, err := llcm.LoadJournal(cmd.String(rollbackJournal.Name))
 		if err != nil {
 			return err
 		}
+
+		// run rollback operation
+		// data is returned together with the errors to report the entries already processed
+		data, err := man.Rollback(ctx, entries)
+		if data == nil {
+			return err
+		}
 		debug(man)
 
-		// logging at process stop with the number of applied entries
+		// sort result
+		llcm.SortEntries(data)
+
+		// create renderer with data
+		ren := llcm.NewRenderer(w, data)
+
+		// set output type passed as string
+		if err := ren.SetOutputType(cmd.String(output.Name)); err != nil {
+			return err
+		}
+
+		// render result
+		if err := ren.Render(); err != nil {
+			return err
+		}
+
+		// logging per-account totals when multiple accounts are found
+		accountTotal(data.TotalByAccount())
+
+		// logging the retries and throttled attempts of the api calls
+		retryStats(man)
+
+		// logging at process stop with the number of restored, failed and skipped entries
+		total := data.Total()
 		logger.Info(
 			"stopped",
-			"applied", n,
+			llcm.TotalAppliedLabel, total[llcm.TotalAppliedLabel],
+			llcm.TotalFailedLabel, total[llcm.TotalFailedLabel],
+			llcm.TotalSkippedLabel, total[llcm.TotalSkippedLabel],
 		)
 
-		return nil
+		// render the error summary when continuing on error
+		return summarize(cmd, err)
 	}
 
 	return &cli.Command{
@@ -277,26 +899,34 @@ func newCmd(w, ew io.Writer) *cli.Command {
 			{
 				Name:        "list",
 				Usage:       "List log group entries with specified format",
-				Description: "List collects basic information about log groups from multiple specified regions and\nreturns it in a specified format.",
+				Description: "List collects basic information about log groups from multiple specified accounts and\nregions and returns it in a specified format.",
 				Before:      before,
 				Action:      list,
-				Flags:       []cli.Flag{profile, loglevel, region, filter, output},
+				Flags:       []cli.Flag{profile, loglevel, roleARN, accountsFile, region, filter, continueOnError, withTags, tagColumns, withIdle, withOrphan, output},
 			},
 			{
 				Name:        "preview",
 				Usage:       "Preview simulation results based on desired state",
-				Description: "Preview performs a simple calculation based on `DesiredState` specified in the argument\nand returns a simulated list including `ReducibleBytes`, `RemainingBytes`, etc.",
+				Description: "Preview performs a simple calculation based on `DesiredState` specified in the argument\nand returns a simulated list including `ReducibleBytes`, `RemainingBytes`, etc.\nThe result can be saved as a plan for apply.",
 				Before:      before,
 				Action:      preview,
-				Flags:       []cli.Flag{profile, loglevel, region, filter, desired, output},
+				Flags:       []cli.Flag{profile, loglevel, roleARN, accountsFile, region, filter, resultFilter, continueOnError, withTags, tagColumns, withIdle, withOrphan, desired, defaultState, round, policy, mode, forceUnprotect, out, sortBy, output},
 			},
 			{
 				Name:        "apply",
 				Usage:       "Apply desired state to log group entries",
-				Description: "Apply deletes and updates target log groups in batches based on `DesiredState`.\nIt is fast across multiple regions, but cleverly avoids throttling.",
+				Description: "Apply deletes and updates target log groups in batches based on `DesiredState`.\nIt is fast across multiple regions, but cleverly avoids throttling.\nIt asks for confirmation with the number of target log groups and their stored bytes.\nProtected log groups are not deleted unless forced to unprotect.\nWith a saved plan, it acts only on the planned log groups after checking for drift.",
 				Before:      before,
 				Action:      apply,
-				Flags:       []cli.Flag{profile, loglevel, region, filter, desired},
+				Flags:       []cli.Flag{profile, loglevel, roleARN, accountsFile, region, filter, resultFilter, continueOnError, withTags, tagColumns, withIdle, withOrphan, desired, defaultState, round, policy, mode, forceUnprotect, plan, allowDrift, journal, tagApplied, dryRun, maxChanges, maxDeletedBytes, yes, sortBy, output},
+			},
+			{
+				Name:        "rollback",
+				Usage:       "Restore log group entries to the prior state recorded by apply",
+				Description: "Rollback restores the retention and deletion protection of log groups to the prior state\nrecorded in the journal written by apply. Deleted log groups cannot be restored and are\nreported as skipped.",
+				Before:      before,
+				Action:      rollback,
+				Flags:       []cli.Flag{profile, loglevel, roleARN, accountsFile, rollbackJournal, continueOnError, output},
 			},
 		},
 	}
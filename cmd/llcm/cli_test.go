@@ -2,8 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"reflect"
 	"testing"
+
+	"github.com/nekrassov01/llcm"
 )
 
 func Test_cli(t *testing.T) {
@@ -37,6 +42,116 @@ func Test_cli(t *testing.T) {
 			args:    []string{name, "preview", "-d", "unknown"},
 			wantErr: true,
 		},
+		{
+			name:    "no desired state nor policy",
+			args:    []string{name, "preview"},
+			wantErr: true,
+		},
+		{
+			name:    "both desired state and policy",
+			args:    []string{name, "preview", "-d", "1day", "-P", "policy.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown policy file",
+			args:    []string{name, "apply", "-P", "unknown.yaml"},
+			wantErr: true,
+		},
+		{
+			name:    "both plan and desired state",
+			args:    []string{name, "apply", "--plan", "plan.json", "-d", "1day"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown plan file",
+			args:    []string{name, "apply", "--plan", "unknown.json"},
+			wantErr: true,
+		},
+		{
+			name:    "negative max changes",
+			args:    []string{name, "apply", "-d", "1day", "--max-changes", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "negative max deleted bytes",
+			args:    []string{name, "apply", "-d", "delete", "--max-deleted-bytes", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown sort key",
+			args:    []string{name, "preview", "-d", "1day", "--sort", "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "sort key with jsonl output",
+			args:    []string{name, "preview", "-d", "1day", "-o", "jsonl", "--sort", "action"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			args:    []string{name, "preview", "-d", "1day", "--mode", "unknown"},
+			wantErr: true,
+		},
+		{
+			name:    "both plan and mode",
+			args:    []string{name, "apply", "--plan", "plan.json", "--mode", "shorten-only"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown round",
+			args:    []string{name, "preview", "--desired", "100", "--round", "nearest"},
+			wantErr: true,
+		},
+		{
+			name:    "desired not allowed without round",
+			args:    []string{name, "preview", "--desired", "100"},
+			wantErr: true,
+		},
+		{
+			name:    "both policy and round",
+			args:    []string{name, "preview", "--policy", "policy.yaml", "--round", "up"},
+			wantErr: true,
+		},
+		{
+			name:    "default without from tag",
+			args:    []string{name, "preview", "--desired", "1year", "--default", "1month"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid default",
+			args:    []string{name, "preview", "--desired", "from-tag", "--default", "forever"},
+			wantErr: true,
+		},
+		{
+			name:    "both policy and default",
+			args:    []string{name, "preview", "--policy", "policy.yaml", "--default", "1month"},
+			wantErr: true,
+		},
+		{
+			name:    "both plan and result filter",
+			args:    []string{name, "apply", "--plan", "plan.json", "--result-filter", "reducibleBytes > 0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid result filter",
+			args:    []string{name, "preview", "--desired", "1year", "--result-filter", "["},
+			wantErr: true,
+		},
+		{
+			name:    "both plan and force unprotect",
+			args:    []string{name, "apply", "--plan", "plan.json", "--force-unprotect"},
+			wantErr: true,
+		},
+		{
+			name:    "rollback without journal",
+			args:    []string{name, "rollback"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown journal file",
+			args:    []string{name, "rollback", "--journal", "unknown.jsonl"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -48,3 +163,85 @@ func Test_cli(t *testing.T) {
 		})
 	}
 }
+
+func Test_exitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "error",
+			err:  errors.New("error"),
+			want: exitCodeError,
+		},
+		{
+			name: "partial failure",
+			err:  fmt.Errorf("wrapped: %w", llcm.Errors{}),
+			want: exitCodePartialFailure,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.err); got != tt.want {
+				t.Errorf("exitCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitTagIssues(t *testing.T) {
+	issues := llcm.TagIssues{{LogGroupName: "group0"}}
+	fatal := errors.New("AccessDenied")
+	tests := []struct {
+		name       string
+		err        error
+		wantIssues llcm.TagIssues
+		wantErr    error
+	}{
+		{
+			name:       "nil",
+			err:        nil,
+			wantIssues: nil,
+			wantErr:    nil,
+		},
+		{
+			name:       "tag issues only",
+			err:        errors.Join(nil, issues),
+			wantIssues: issues,
+			wantErr:    nil,
+		},
+		{
+			name:       "fatal error only",
+			err:        fatal,
+			wantIssues: nil,
+			wantErr:    fatal,
+		},
+		{
+			name:       "fatal error with tag issues",
+			err:        errors.Join(fatal, issues),
+			wantIssues: issues,
+			wantErr:    fatal,
+		},
+		{
+			name:       "partial failure with tag issues",
+			err:        errors.Join(llcm.Errors{{LogGroupName: "group1"}}, issues),
+			wantIssues: issues,
+			wantErr:    llcm.Errors{{LogGroupName: "group1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIssues, gotErr := splitTagIssues(tt.err)
+			if !reflect.DeepEqual(gotIssues, tt.wantIssues) {
+				t.Errorf("splitTagIssues() issues = %v, want %v", gotIssues, tt.wantIssues)
+			}
+			if !reflect.DeepEqual(gotErr, tt.wantErr) {
+				t.Errorf("splitTagIssues() error = %v, want %v", gotErr, tt.wantErr)
+			}
+			if tt.wantErr != nil && exitCode(gotErr) != exitCode(tt.wantErr) {
+				t.Errorf("exitCode() = %v, want %v", exitCode(gotErr), exitCode(tt.wantErr))
+			}
+		})
+	}
+}
@@ -10,6 +10,6 @@ func main() {
 	cmd := newCmd(os.Stdout, os.Stderr)
 	if err := cmd.Run(ctx, os.Args); err != nil {
 		logger.Error(err.Error())
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
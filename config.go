@@ -1,8 +1,11 @@
 package llcm
 
 import (
+	"bufio"
 	"context"
-	"time"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -35,9 +38,6 @@ var (
 )
 
 var (
-	// nowFunc is used to mock time in tests.
-	nowFunc = time.Now
-
 	// allowedRegions is the list of allowed regions.
 	allowedRegions = map[string]struct{}{
 		"af-south-1":     {},
@@ -94,3 +94,28 @@ func LoadConfig(ctx context.Context, profile string) (aws.Config, error) {
 	}
 	return cfg, nil
 }
+
+// LoadRoleARNs loads the role ARNs of the target accounts from the specified file.
+// The file has one role ARN per line. Blank lines and lines starting with '#' are ignored.
+func LoadRoleARNs(path string) ([]string, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	var roleARNs []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		roleARNs = append(roleARNs, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return roleARNs, nil
+}
@@ -2,6 +2,9 @@ package llcm
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -43,3 +46,65 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadRoleARNs(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "accounts.txt")
+	content := "# accounts\narn:aws:iam::111111111111:role/llcm\n\n  arn:aws:iam::222222222222:role/llcm  \n"
+	if err := os.WriteFile(valid, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				path: valid,
+			},
+			want: []string{
+				"arn:aws:iam::111111111111:role/llcm",
+				"arn:aws:iam::222222222222:role/llcm",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty",
+			args: args{
+				path: empty,
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			args: args{
+				path: filepath.Join(dir, "unknown.txt"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadRoleARNs(tt.args.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadRoleARNs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadRoleARNs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
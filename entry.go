@@ -3,6 +3,7 @@ package llcm
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
@@ -12,6 +13,7 @@ var (
 	retentionInDaysLabel = "retentionInDays"
 	storedBytesLabel     = "storedBytes"
 	desiredStateLabel    = "desiredState"
+	actionLabel          = "action"
 	reducibleBytesLabel  = "reducibleBytes"
 	remainingBytesLabel  = "remainingBytes"
 )
@@ -19,28 +21,45 @@ var (
 var (
 	_ Entry        = (*ListEntry)(nil)
 	_ Entry        = (*PreviewEntry)(nil)
+	_ Entry        = (*ApplyEntry)(nil)
 	_ filterTarget = (*entry)(nil)
+	_ filterTarget = (*PreviewEntry)(nil)
 )
 
 // Entry is an interface for log group entry.
 type Entry interface {
 	Name() string              // Name returns the name of the entry.
 	DataSet() map[string]int64 // DataSet returns map for plotting the chart.
+	account() string           // account returns the account ID of the entry.
 	toInput() []any            // toInput returns the input of the entry for rendering.
 	toTSV() []string           // toTSV returns the TSV of the entry for rendering.
 }
 
 // entry represents the base entry for log group.
 type entry struct {
-	LogGroupName       string              // The name of the log group.
-	Region             string              // The region that the log group belongs to.
-	Class              types.LogGroupClass // The class of the log group.
-	CreatedAt          time.Time           // The time when the log group was created.
-	DeletionProtection bool                // Whether the log group is protected to deletion.
-	ElapsedDays        int64               // The number of days elapsed since the log group was created.
-	RetentionInDays    int64               // The retention days of the log group.
-	StoredBytes        int64               // The stored bytes of the log group.
-	name               *string             // The native type of LogGroupName.
+	LogGroupName         string                     // The name of the log group.
+	AccountID            string                     // The account ID that owns the log group.
+	Region               string                     // The region that the log group belongs to.
+	Class                types.LogGroupClass        // The class of the log group.
+	CreatedAt            time.Time                  // The time when the log group was created.
+	DeletionProtection   bool                       // Whether the log group is protected to deletion.
+	ElapsedDays          int64                      // The number of days elapsed since the log group was created.
+	RetentionInDays      int64                      // The retention days of the log group.
+	StoredBytes          int64                      // The stored bytes of the log group.
+	Arn                  string                     // The ARN of the log group without the trailing wildcard.
+	KmsKeyId             string                     // The ID of the KMS key to encrypt the log group, or empty if not encrypted.
+	DataProtectionStatus types.DataProtectionStatus // The status of the data protection policy of the log group.
+	MetricFilterCount    int64                      // The number of metric filters of the log group.
+	InheritedProperties  []types.InheritedProperty  // The properties that the log group inherits from the account.
+	Tags                 map[string]string          `json:",omitempty"` // The tags of the log group, fetched only if needed.
+	LastEventAt          time.Time                  `json:",omitzero"`  // The time of the last event of the log group, fetched only if needed.
+	IdleDays             int64                      `json:",omitzero"`  // The number of days since the last event, or since created without any event.
+	Orphaned             bool                       `json:",omitempty"` // Whether the resource that owns the log group no longer exists, checked only if needed.
+	name                 *string                    // The native type of LogGroupName.
+	client               *Client                    // The client for the account that owns the log group.
+	tagColumns           []string                   // The tag keys rendered as the extra columns.
+	idleColumns          bool                       // Whether the last event is rendered as the extra columns.
+	orphanColumns        bool                       // Whether Orphaned is rendered as the extra column.
 }
 
 // Name returns the name of the entry.
@@ -48,14 +67,25 @@ func (e *entry) Name() string {
 	return e.LogGroupName
 }
 
+// account returns the account ID that owns the log group.
+func (e *entry) account() string {
+	return e.AccountID
+}
+
 // GetField returns the value of the specified field.
 // This implements the filer.Target interface.
 func (e *entry) GetField(key string) (any, error) {
 	switch key {
 	case "name", "Name", "LogGroupName":
 		return e.LogGroupName, nil
+	case "account", "Account", "AccountID":
+		return e.AccountID, nil
+	case "region", "Region":
+		return e.Region, nil
 	case "class", "Class", "LogGroupClass":
 		return string(e.Class), nil
+	case "created", "Created", "createdAt", "CreatedAt":
+		return e.CreatedAt, nil
 	case "protected", "Protected", "DeletionProtection":
 		return e.DeletionProtection, nil
 	case "elapsed", "Elapsed", "ElapsedDays":
@@ -64,11 +94,109 @@ func (e *entry) GetField(key string) (any, error) {
 		return e.RetentionInDays, nil
 	case "bytes", "Bytes", "StoredBytes":
 		return e.StoredBytes, nil
+	case "arn", "Arn", "LogGroupArn":
+		return e.Arn, nil
+	case "kms", "Kms", "KmsKeyId":
+		return e.KmsKeyId, nil
+	case "dataProtection", "DataProtection", "DataProtectionStatus":
+		return string(e.DataProtectionStatus), nil
+	case "metricFilters", "MetricFilters", "MetricFilterCount":
+		return e.MetricFilterCount, nil
+	case "inherited", "Inherited", "InheritedProperties":
+		return e.inheritedProperties(), nil
+	case "idle", "Idle", "IdleDays":
+		return e.IdleDays, nil
+	case "lastEvent", "LastEvent", "lastEventAt", "LastEventAt":
+		return e.LastEventAt, nil
+	case "orphaned", "Orphaned":
+		return e.Orphaned, nil
 	default:
+		if k, ok := tagKey(key); ok {
+			return e.Tags[k], nil
+		}
 		return 0, fmt.Errorf("field not found: %q", key)
 	}
 }
 
+// tagValues returns the values of the tags selected as the extra columns, empty if not tagged.
+func (e *entry) tagValues() []string {
+	s := make([]string, len(e.tagColumns))
+	for i, k := range e.tagColumns {
+		s[i] = e.Tags[k]
+	}
+	return s
+}
+
+// extraValues returns the values of the extra columns: the last event and the orphaned if fetched,
+// followed by the tags selected.
+func (e *entry) extraValues() []string {
+	var s []string
+	if e.idleColumns {
+		s = append(s, e.lastEventAt(), strconv.FormatInt(e.IdleDays, 10))
+	}
+	if e.orphanColumns {
+		s = append(s, strconv.FormatBool(e.Orphaned))
+	}
+	return append(s, e.tagValues()...)
+}
+
+// withExtraValues returns the input for rendering followed by the values of the extra columns.
+func (e *entry) withExtraValues(input []any) []any {
+	if e.idleColumns {
+		input = append(input, e.lastEventAt(), e.IdleDays)
+	}
+	if e.orphanColumns {
+		input = append(input, e.Orphaned)
+	}
+	for _, v := range e.tagValues() {
+		input = append(input, v)
+	}
+	return input
+}
+
+// inheritedProperties returns the inherited properties joined with commas.
+func (e *entry) inheritedProperties() string {
+	s := make([]string, len(e.InheritedProperties))
+	for i, p := range e.InheritedProperties {
+		s[i] = string(p)
+	}
+	return strings.Join(s, ",")
+}
+
+// action returns the action that the desired state requires for the log group, and the reason if skipped.
+// Deleting a protected log group is skipped because it fails without unprotecting first, unless forced to unprotect.
+// Changing the retention in the direction that the mode does not allow is also skipped.
+func (e *entry) action(desired DesiredState, mode Mode, forceUnprotect bool) (Action, string) {
+	switch desired {
+	case DesiredStateNone:
+		return ActionNone, ""
+	case DesiredStateZero:
+		if mode == ModeLengthenOnly {
+			return ActionSkip, "deletion is not allowed in " + mode.String() + " mode"
+		}
+		if e.DeletionProtection && !forceUnprotect {
+			return ActionSkip, "log group is protected from deletion"
+		}
+		return ActionDelete, ""
+	case DesiredStateProtected, DesiredStateUnprotected:
+		if e.DeletionProtection == (desired == DesiredStateProtected) {
+			return ActionNoop, ""
+		}
+		return ActionUpdate, ""
+	default:
+		switch {
+		case e.RetentionInDays == int64(desired):
+			return ActionNoop, ""
+		case mode == ModeShortenOnly && e.RetentionInDays < int64(desired):
+			return ActionSkip, "lengthening retention is not allowed in " + mode.String() + " mode"
+		case mode == ModeLengthenOnly && e.RetentionInDays > int64(desired):
+			return ActionSkip, "shortening retention is not allowed in " + mode.String() + " mode"
+		default:
+			return ActionUpdate, ""
+		}
+	}
+}
+
 // ListEntry represents an entry to list log group.
 type ListEntry struct {
 	*entry
@@ -84,8 +212,9 @@ func (e *ListEntry) DataSet() map[string]int64 {
 
 // toInput returns the input of the list entry for rendering.
 func (e *ListEntry) toInput() []any {
-	return []any{
+	return e.withExtraValues([]any{
 		e.LogGroupName,
+		e.AccountID,
 		e.Region,
 		e.Class,
 		e.CreatedAt.Format(time.RFC3339),
@@ -93,13 +222,19 @@ func (e *ListEntry) toInput() []any {
 		e.ElapsedDays,
 		e.RetentionInDays,
 		e.StoredBytes,
-	}
+		e.Arn,
+		e.KmsKeyId,
+		e.DataProtectionStatus,
+		e.MetricFilterCount,
+		e.inheritedProperties(),
+	})
 }
 
 // toTSV returns the tab-separated values of the list entry for rendering.
 func (e *ListEntry) toTSV() []string {
-	return []string{
+	return append([]string{
 		e.LogGroupName,
+		e.AccountID,
 		e.Region,
 		string(e.Class),
 		e.CreatedAt.Format(time.RFC3339),
@@ -107,7 +242,12 @@ func (e *ListEntry) toTSV() []string {
 		strconv.FormatInt(e.ElapsedDays, 10),
 		strconv.FormatInt(e.RetentionInDays, 10),
 		strconv.FormatInt(e.StoredBytes, 10),
-	}
+		e.Arn,
+		e.KmsKeyId,
+		string(e.DataProtectionStatus),
+		strconv.FormatInt(e.MetricFilterCount, 10),
+		e.inheritedProperties(),
+	}, e.extraValues()...)
 }
 
 // PreviewEntry is an extended representation of entry with the desired state and its simulated results.
@@ -116,17 +256,56 @@ type PreviewEntry struct {
 
 	BytesPerDay     int64        // The bytes per day of the log group.
 	DesiredState    DesiredState // The desired state of the log group.
+	Rule            string       // The name of the policy rule that resolved the desired state.
+	Action          Action       // The action that the desired state requires.
+	Reason          string       // The reason why the log group is skipped.
 	ReductionInDays int64        // The number of days to be reduced after the action.
 	ReducibleBytes  int64        // The number of bytes that can be reduced after the action.
 	RemainingBytes  int64        // The number of bytes that remain after the action.
 }
 
+// resultKeys is the list of the filter keys that refer to the simulated results,
+// which are available only in the result filter.
+var resultKeys = []string{
+	"bytesPerDay", "BytesPerDay",
+	"desiredState", "DesiredState",
+	"action", "Action",
+	"reason", "Reason",
+	"reductionInDays", "ReductionInDays",
+	"reducibleBytes", "ReducibleBytes",
+	"remainingBytes", "RemainingBytes",
+}
+
+// GetField returns the value of the specified field.
+// In addition to the fields of the entry, the simulated results can be specified.
+func (e *PreviewEntry) GetField(key string) (any, error) {
+	switch key {
+	case "bytesPerDay", "BytesPerDay":
+		return e.BytesPerDay, nil
+	case "desiredState", "DesiredState":
+		return e.DesiredState.String(), nil
+	case "action", "Action":
+		return e.Action.String(), nil
+	case "reason", "Reason":
+		return e.Reason, nil
+	case "reductionInDays", "ReductionInDays":
+		return e.ReductionInDays, nil
+	case "reducibleBytes", "ReducibleBytes":
+		return e.ReducibleBytes, nil
+	case "remainingBytes", "RemainingBytes":
+		return e.RemainingBytes, nil
+	default:
+		return e.entry.GetField(key)
+	}
+}
+
 // DataSet returns map for plotting the chart.
 func (e *PreviewEntry) DataSet() map[string]int64 {
 	return map[string]int64{
 		retentionInDaysLabel: e.RetentionInDays,
 		storedBytesLabel:     e.StoredBytes,
 		desiredStateLabel:    int64(e.DesiredState),
+		actionLabel:          int64(e.Action),
 		reducibleBytesLabel:  e.ReducibleBytes,
 		remainingBytesLabel:  e.RemainingBytes,
 	}
@@ -134,8 +313,9 @@ func (e *PreviewEntry) DataSet() map[string]int64 {
 
 // toInput returns the input of the desired entry for rendering.
 func (e *PreviewEntry) toInput() []any {
-	return []any{
+	return e.withExtraValues([]any{
 		e.LogGroupName,
+		e.AccountID,
 		e.Region,
 		e.Class,
 		e.CreatedAt.Format(time.RFC3339),
@@ -143,18 +323,27 @@ func (e *PreviewEntry) toInput() []any {
 		e.ElapsedDays,
 		e.RetentionInDays,
 		e.StoredBytes,
+		e.Arn,
+		e.KmsKeyId,
+		e.DataProtectionStatus,
+		e.MetricFilterCount,
+		e.inheritedProperties(),
 		e.BytesPerDay,
 		DesiredState(e.DesiredState).String(),
+		e.Rule,
+		e.Action.String(),
+		e.Reason,
 		e.ReductionInDays,
 		e.ReducibleBytes,
 		e.RemainingBytes,
-	}
+	})
 }
 
 // toTSV returns the tab-separated values of the desired entry for rendering.
 func (e *PreviewEntry) toTSV() []string {
-	return []string{
+	return append([]string{
 		e.LogGroupName,
+		e.AccountID,
 		e.Region,
 		string(e.Class),
 		e.CreatedAt.Format(time.RFC3339),
@@ -162,17 +351,119 @@ func (e *PreviewEntry) toTSV() []string {
 		strconv.FormatInt(e.ElapsedDays, 10),
 		strconv.FormatInt(e.RetentionInDays, 10),
 		strconv.FormatInt(e.StoredBytes, 10),
+		e.Arn,
+		e.KmsKeyId,
+		string(e.DataProtectionStatus),
+		strconv.FormatInt(e.MetricFilterCount, 10),
+		e.inheritedProperties(),
 		strconv.FormatInt(e.BytesPerDay, 10),
 		DesiredState(e.DesiredState).String(),
+		e.Rule,
+		e.Action.String(),
+		e.Reason,
 		strconv.FormatInt(e.ReductionInDays, 10),
 		strconv.FormatInt(e.ReducibleBytes, 10),
 		strconv.FormatInt(e.RemainingBytes, 10),
+	}, e.extraValues()...)
+}
+
+// ApplyEntry is an extended representation of entry with the result of applying the desired state.
+type ApplyEntry struct {
+	*entry
+
+	DesiredState     DesiredState  // The desired state applied to the log group.
+	Action           Action        // The action taken for the log group.
+	RetentionBefore  int64         // The retention days before the action.
+	RetentionAfter   int64         // The retention days after the action.
+	ProtectionBefore bool          // Whether the log group was protected to deletion before the action.
+	ProtectionAfter  bool          // Whether the log group is protected to deletion after the action.
+	Unprotected      bool          // Whether the deletion protection was disabled to delete the log group.
+	Success          bool          // Whether the action succeeded.
+	Error            string        // The error message, or the reason why the log group was skipped.
+	TagError         string        `json:",omitempty"` // The error message of tagging the log group after the action.
+	Duration         time.Duration // The time taken for the action.
+}
+
+// newApplyEntry creates a new apply entry with the current state of the log group as before and after.
+func newApplyEntry(entry *entry, desired DesiredState) *ApplyEntry {
+	return &ApplyEntry{
+		entry:            entry,
+		DesiredState:     desired,
+		Action:           ActionNone,
+		RetentionBefore:  entry.RetentionInDays,
+		RetentionAfter:   entry.RetentionInDays,
+		ProtectionBefore: entry.DeletionProtection,
+		ProtectionAfter:  entry.DeletionProtection,
+	}
+}
+
+// DataSet returns map for plotting the chart.
+func (e *ApplyEntry) DataSet() map[string]int64 {
+	return map[string]int64{
+		retentionInDaysLabel: e.RetentionAfter,
+		storedBytesLabel:     e.StoredBytes,
+		desiredStateLabel:    int64(e.DesiredState),
+		actionLabel:          int64(e.Action),
+	}
+}
+
+// totalLabel returns the label of the total that the entry is counted in.
+// The log groups already in the desired state are counted as skipped.
+func (e *ApplyEntry) totalLabel() string {
+	switch {
+	case e.Action == ActionSkip, e.Action == ActionNoop:
+		return TotalSkippedLabel
+	case e.Success:
+		return TotalAppliedLabel
+	default:
+		return TotalFailedLabel
 	}
 }
 
+// toInput returns the input of the apply entry for rendering.
+func (e *ApplyEntry) toInput() []any {
+	return e.withExtraValues([]any{
+		e.LogGroupName,
+		e.AccountID,
+		e.Region,
+		e.StoredBytes,
+		e.DesiredState.String(),
+		e.Action.String(),
+		e.RetentionBefore,
+		e.RetentionAfter,
+		e.ProtectionBefore,
+		e.ProtectionAfter,
+		e.Unprotected,
+		e.Success,
+		e.Error,
+		e.Duration.String(),
+	})
+}
+
+// toTSV returns the tab-separated values of the apply entry for rendering.
+func (e *ApplyEntry) toTSV() []string {
+	return append([]string{
+		e.LogGroupName,
+		e.AccountID,
+		e.Region,
+		strconv.FormatInt(e.StoredBytes, 10),
+		e.DesiredState.String(),
+		e.Action.String(),
+		strconv.FormatInt(e.RetentionBefore, 10),
+		strconv.FormatInt(e.RetentionAfter, 10),
+		strconv.FormatBool(e.ProtectionBefore),
+		strconv.FormatBool(e.ProtectionAfter),
+		strconv.FormatBool(e.Unprotected),
+		strconv.FormatBool(e.Success),
+		e.Error,
+		e.Duration.String(),
+	}, e.extraValues()...)
+}
+
 // simulate calculates the simulated results for the log group.
-func (e *PreviewEntry) simulate(desired DesiredState) {
+func (e *PreviewEntry) simulate(desired DesiredState, mode Mode, forceUnprotect bool) {
 	e.setDesiredState(desired)
+	e.setAction(mode, forceUnprotect)
 	e.setBytesPerDay()
 	e.setReductionInDays()
 	e.setReducibleBytes()
@@ -184,6 +475,11 @@ func (e *PreviewEntry) setDesiredState(desired DesiredState) {
 	e.DesiredState = desired
 }
 
+// setAction sets the action that the desired state requires in the mode, and the reason if skipped.
+func (e *PreviewEntry) setAction(mode Mode, forceUnprotect bool) {
+	e.Action, e.Reason = e.action(e.DesiredState, mode, forceUnprotect)
+}
+
 // setBytesPerDay sets the bytes per day for the log group.
 func (e *PreviewEntry) setBytesPerDay() {
 	if e.StoredBytes <= 0 {
@@ -208,6 +504,10 @@ func (e *PreviewEntry) setBytesPerDay() {
 
 // setReductionInDays sets the expected reduction in days after action.
 func (e *PreviewEntry) setReductionInDays() {
+	if e.Action == ActionSkip {
+		e.ReductionInDays = 0
+		return
+	}
 	if e.DesiredState > 9999 {
 		e.ReductionInDays = 0
 		return
@@ -1,8 +1,11 @@
 package llcm
 
+import "time"
+
 var (
 	_ EntryData[*ListEntry]    = (*ListEntryData)(nil)
 	_ EntryData[*PreviewEntry] = (*PreviewEntryData)(nil)
+	_ EntryData[*ApplyEntry]   = (*ApplyEntryData)(nil)
 )
 
 var (
@@ -14,12 +17,22 @@ var (
 
 	// TotalRemainingBytesLabel is the label of the total remaining bytes.
 	TotalRemainingBytesLabel = "remainingBytes"
+
+	// TotalAppliedLabel is the label of the total number of applied log groups.
+	TotalAppliedLabel = "applied"
+
+	// TotalFailedLabel is the label of the total number of failed log groups.
+	TotalFailedLabel = "failed"
+
+	// TotalSkippedLabel is the label of the total number of skipped log groups.
+	TotalSkippedLabel = "skipped"
 )
 
 var (
 	// listEntryDataHeader is the header of ListEntryData.
 	listEntryDataHeader = []string{
 		"Name",
+		"AccountID",
 		"Region",
 		"Class",
 		"CreatedAt",
@@ -27,11 +40,17 @@ var (
 		"ElapsedDays",
 		"RetentionInDays",
 		"StoredBytes",
+		"Arn",
+		"KmsKeyId",
+		"DataProtectionStatus",
+		"MetricFilterCount",
+		"InheritedProperties",
 	}
 
 	// previewEntryDataHeader is the header of PreviewEntryData.
 	previewEntryDataHeader = []string{
 		"Name",
+		"AccountID",
 		"Region",
 		"Class",
 		"CreatedAt",
@@ -39,12 +58,38 @@ var (
 		"ElapsedDays",
 		"RetentionInDays",
 		"StoredBytes",
+		"Arn",
+		"KmsKeyId",
+		"DataProtectionStatus",
+		"MetricFilterCount",
+		"InheritedProperties",
 		"BytesPerDay",
 		"DesiredState",
+		"Rule",
+		"Action",
+		"Reason",
 		"ReductionInDays",
 		"ReducibleBytes",
 		"RemainingBytes",
 	}
+
+	// applyEntryDataHeader is the header of ApplyEntryData.
+	applyEntryDataHeader = []string{
+		"Name",
+		"AccountID",
+		"Region",
+		"StoredBytes",
+		"DesiredState",
+		"Action",
+		"RetentionBefore",
+		"RetentionAfter",
+		"ProtectionBefore",
+		"ProtectionAfter",
+		"Unprotected",
+		"Success",
+		"Error",
+		"Duration",
+	}
 )
 
 // EntryData represents the collection of entries.
@@ -52,6 +97,7 @@ type EntryData[T Entry] interface {
 	Header() []string
 	Entries() []T
 	Total() map[string]int64
+	TotalByAccount() map[string]map[string]int64
 	Chart() error
 }
 
@@ -59,8 +105,9 @@ type EntryData[T Entry] interface {
 type ListEntryData struct {
 	TotalStoredBytes int64 // The total stored bytes of the log groups.
 
-	header  []string
-	entries []*ListEntry
+	header           []string
+	entries          []*ListEntry
+	maxPieChartItems int
 }
 
 // Header returns the header of the ListEntryData.
@@ -83,12 +130,28 @@ func (d *ListEntryData) Total() map[string]int64 {
 	}
 }
 
+// TotalByAccount returns the total of the ListEntryData for each account.
+func (d *ListEntryData) TotalByAccount() map[string]map[string]int64 {
+	m := make(map[string]map[string]int64)
+	for _, e := range d.entries {
+		total, ok := m[e.AccountID]
+		if !ok {
+			total = map[string]int64{
+				TotalStoredBytesLabel: 0,
+			}
+			m[e.AccountID] = total
+		}
+		total[TotalStoredBytesLabel] += e.StoredBytes
+	}
+	return m
+}
+
 // Chart generates a pie chart for the ListEntryData.
 func (d *ListEntryData) Chart() error {
 	if len(d.entries) == 0 {
 		return nil
 	}
-	items := getPieItems(d.entries)
+	items := getPieItems(d.entries, d.maxPieChartItems)
 	chart := newPieChart(items)
 	if chart == nil {
 		return nil
@@ -102,8 +165,12 @@ type PreviewEntryData struct {
 	TotalReducibleBytes int64 // The total reducible bytes of the log groups.
 	TotalRemainingBytes int64 // The total remaining bytes of the log groups.
 
-	header  []string
-	entries []*PreviewEntry
+	header         []string
+	entries        []*PreviewEntry
+	mode           Mode
+	forceUnprotect bool
+	previewedAt    time.Time
+	tagIssues      TagIssues
 }
 
 // Header returns the header of the PreviewEntryData.
@@ -119,6 +186,11 @@ func (d *PreviewEntryData) Entries() []*PreviewEntry {
 	return d.entries
 }
 
+// TagIssues returns the log groups whose tags do not declare the desired state, sorted by account, region and name.
+func (d *PreviewEntryData) TagIssues() TagIssues {
+	return d.tagIssues
+}
+
 // Total returns the total of the PreviewEntryData.
 func (d *PreviewEntryData) Total() map[string]int64 {
 	return map[string]int64{
@@ -128,6 +200,26 @@ func (d *PreviewEntryData) Total() map[string]int64 {
 	}
 }
 
+// TotalByAccount returns the total of the PreviewEntryData for each account.
+func (d *PreviewEntryData) TotalByAccount() map[string]map[string]int64 {
+	m := make(map[string]map[string]int64)
+	for _, e := range d.entries {
+		total, ok := m[e.AccountID]
+		if !ok {
+			total = map[string]int64{
+				TotalStoredBytesLabel:    0,
+				TotalReducibleBytesLabel: 0,
+				TotalRemainingBytesLabel: 0,
+			}
+			m[e.AccountID] = total
+		}
+		total[TotalStoredBytesLabel] += e.StoredBytes
+		total[TotalReducibleBytesLabel] += e.ReducibleBytes
+		total[TotalRemainingBytesLabel] += e.RemainingBytes
+	}
+	return m
+}
+
 // Chart generates a bar chart for the PreviewEntryData.
 func (d *PreviewEntryData) Chart() error {
 	if len(d.entries) == 0 {
@@ -141,3 +233,91 @@ func (d *PreviewEntryData) Chart() error {
 	}
 	return render(chart)
 }
+
+// ApplyEntryData represents the collection of ApplyEntry.
+type ApplyEntryData struct {
+	TotalStoredBytes int64 // The total stored bytes of the log groups.
+	TotalApplied     int64 // The total number of log groups applied successfully.
+	TotalFailed      int64 // The total number of log groups failed to apply.
+	TotalSkipped     int64 // The total number of log groups skipped.
+
+	header           []string
+	entries          []*ApplyEntry
+	maxPieChartItems int
+	tagIssues        TagIssues
+}
+
+// Header returns the header of the ApplyEntryData.
+func (d *ApplyEntryData) Header() []string {
+	return d.header
+}
+
+// Entries returns the entries of the ApplyEntryData.
+func (d *ApplyEntryData) Entries() []*ApplyEntry {
+	if len(d.entries) == 0 {
+		return nil
+	}
+	return d.entries
+}
+
+// TagIssues returns the log groups whose tags do not declare the desired state, sorted by account, region and name.
+func (d *ApplyEntryData) TagIssues() TagIssues {
+	return d.tagIssues
+}
+
+// Total returns the total of the ApplyEntryData.
+func (d *ApplyEntryData) Total() map[string]int64 {
+	return map[string]int64{
+		TotalStoredBytesLabel: d.TotalStoredBytes,
+		TotalAppliedLabel:     d.TotalApplied,
+		TotalFailedLabel:      d.TotalFailed,
+		TotalSkippedLabel:     d.TotalSkipped,
+	}
+}
+
+// TotalByAccount returns the total of the ApplyEntryData for each account.
+func (d *ApplyEntryData) TotalByAccount() map[string]map[string]int64 {
+	m := make(map[string]map[string]int64)
+	for _, e := range d.entries {
+		total, ok := m[e.AccountID]
+		if !ok {
+			total = map[string]int64{
+				TotalStoredBytesLabel: 0,
+				TotalAppliedLabel:     0,
+				TotalFailedLabel:      0,
+				TotalSkippedLabel:     0,
+			}
+			m[e.AccountID] = total
+		}
+		total[TotalStoredBytesLabel] += e.StoredBytes
+		total[e.totalLabel()]++
+	}
+	return m
+}
+
+// Chart generates a pie chart for the ApplyEntryData.
+func (d *ApplyEntryData) Chart() error {
+	if len(d.entries) == 0 {
+		return nil
+	}
+	items := getPieItems(d.entries, d.maxPieChartItems)
+	chart := newPieChart(items)
+	if chart == nil {
+		return nil
+	}
+	return render(chart)
+}
+
+// add adds the entry to the ApplyEntryData and updates the totals.
+func (d *ApplyEntryData) add(e *ApplyEntry) {
+	d.entries = append(d.entries, e)
+	d.TotalStoredBytes += e.StoredBytes
+	switch e.totalLabel() {
+	case TotalAppliedLabel:
+		d.TotalApplied++
+	case TotalSkippedLabel:
+		d.TotalSkipped++
+	default:
+		d.TotalFailed++
+	}
+}
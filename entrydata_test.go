@@ -36,6 +36,39 @@ func TestListEntryData_Total(t *testing.T) {
 	}
 }
 
+func TestListEntryData_TotalByAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		data ListEntryData
+		want map[string]map[string]int64
+	}{
+		{
+			name: "basic",
+			data: listEntryData,
+			want: map[string]map[string]int64{
+				"123456789012": {
+					TotalStoredBytesLabel: 1024,
+				},
+				"210987654321": {
+					TotalStoredBytesLabel: 2048,
+				},
+			},
+		},
+		{
+			name: "empty",
+			data: ListEntryData{},
+			want: map[string]map[string]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.TotalByAccount(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListEntryData.TotalByAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestListEntryData_Chart(t *testing.T) {
 	type fields struct {
 		entries []*ListEntry
@@ -111,6 +144,43 @@ func TestPreviewEntryData_Total(t *testing.T) {
 	}
 }
 
+func TestPreviewEntryData_TotalByAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		data PreviewEntryData
+		want map[string]map[string]int64
+	}{
+		{
+			name: "basic",
+			data: previewEntryData,
+			want: map[string]map[string]int64{
+				"123456789012": {
+					TotalStoredBytesLabel:    1024,
+					TotalReducibleBytesLabel: 0,
+					TotalRemainingBytesLabel: 0,
+				},
+				"210987654321": {
+					TotalStoredBytesLabel:    2048,
+					TotalReducibleBytesLabel: 100,
+					TotalRemainingBytesLabel: 100,
+				},
+			},
+		},
+		{
+			name: "empty",
+			data: PreviewEntryData{},
+			want: map[string]map[string]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.TotalByAccount(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PreviewEntryData.TotalByAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestPreviewEntryData_Chart(t *testing.T) {
 	type fields struct {
 		TotalStoredBytes    int64
@@ -154,3 +224,92 @@ func TestPreviewEntryData_Chart(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyEntryData_Total(t *testing.T) {
+	tests := []struct {
+		name string
+		data ApplyEntryData
+		want map[string]int64
+	}{
+		{
+			name: "basic",
+			data: applyEntryData,
+			want: map[string]int64{
+				TotalStoredBytesLabel: 3072,
+				TotalAppliedLabel:     1,
+				TotalFailedLabel:      1,
+				TotalSkippedLabel:     0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.Total(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyEntryData.Total() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyEntryData_TotalByAccount(t *testing.T) {
+	tests := []struct {
+		name string
+		data ApplyEntryData
+		want map[string]map[string]int64
+	}{
+		{
+			name: "basic",
+			data: applyEntryData,
+			want: map[string]map[string]int64{
+				"123456789012": {
+					TotalStoredBytesLabel: 1024,
+					TotalAppliedLabel:     1,
+					TotalFailedLabel:      0,
+					TotalSkippedLabel:     0,
+				},
+				"210987654321": {
+					TotalStoredBytesLabel: 2048,
+					TotalAppliedLabel:     0,
+					TotalFailedLabel:      1,
+					TotalSkippedLabel:     0,
+				},
+			},
+		},
+		{
+			name: "empty",
+			data: ApplyEntryData{},
+			want: map[string]map[string]int64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.TotalByAccount(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyEntryData.TotalByAccount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyEntryData_add(t *testing.T) {
+	d := &ApplyEntryData{}
+	for _, e := range []*ApplyEntry{
+		{entry: &entry{StoredBytes: 1}, Action: ActionUpdate, Success: true},
+		{entry: &entry{StoredBytes: 2}, Action: ActionDelete, Success: false},
+		{entry: &entry{StoredBytes: 4}, Action: ActionSkip, Success: false},
+		{entry: &entry{StoredBytes: 8}, Action: ActionDelete, Success: true},
+	} {
+		d.add(e)
+	}
+	want := map[string]int64{
+		TotalStoredBytesLabel: 15,
+		TotalAppliedLabel:     2,
+		TotalFailedLabel:      1,
+		TotalSkippedLabel:     1,
+	}
+	if got := d.Total(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyEntryData.add() = %v, want %v", got, want)
+	}
+	if got := len(d.Entries()); got != 4 {
+		t.Errorf("ApplyEntryData.add() = %d entries, want %d", got, 4)
+	}
+}
@@ -35,6 +35,9 @@ const (
 
 	// OutputTypeChart is the output type that means pie chart.
 	OutputTypeChart
+
+	// OutputTypeJSONL is the output type that means JSON Lines format.
+	OutputTypeJSONL
 )
 
 // String returns the string representation of the OutputType.
@@ -58,6 +61,8 @@ func (t OutputType) String() string {
 		return "tsv"
 	case OutputTypeChart:
 		return "chart"
+	case OutputTypeJSONL:
+		return "jsonl"
 	default:
 		return ""
 	}
@@ -87,6 +92,8 @@ func ParseOutputType(s string) (OutputType, error) {
 		return OutputTypeTSV, nil
 	case OutputTypeChart.String():
 		return OutputTypeChart, nil
+	case OutputTypeJSONL.String():
+		return OutputTypeJSONL, nil
 	default:
 		return OutputTypeNone, fmt.Errorf("unsupported output type: %q", s)
 	}
@@ -245,8 +252,30 @@ func (t DesiredState) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.String())
 }
 
+// UnmarshalJSON parses the JSON representation of the DesiredState.
+func (t *DesiredState) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	d, err := ParseDesiredState(s)
+	if err != nil {
+		return err
+	}
+	*t = d
+	return nil
+}
+
 // ParseDesiredState parses a string into a DesiredState.
+// In addition to the names, a raw day count, an ISO-8601 duration and a Go duration are accepted
+// as long as they are one of the retention values allowed by CloudWatch Logs.
 func ParseDesiredState(s string) (DesiredState, error) {
+	return ParseDesiredStateWithRound(s, RoundNone)
+}
+
+// ParseDesiredStateWithRound parses a string into a DesiredState like ParseDesiredState,
+// but snaps the retention that is not allowed to the nearest allowed one in the direction of the round.
+func ParseDesiredStateWithRound(s string, round Round) (DesiredState, error) {
 	switch s {
 	case DesiredStateZero.String():
 		return DesiredStateZero, nil
@@ -301,6 +330,152 @@ func ParseDesiredState(s string) (DesiredState, error) {
 	case DesiredStateUnprotected.String():
 		return DesiredStateUnprotected, nil
 	default:
-		return DesiredStateNone, fmt.Errorf("unsupported desired state: %q", s)
+		return parseRetention(s, round)
+	}
+}
+
+// Action represents the action taken for the log group.
+type Action int
+
+const (
+	// ActionNone is the action that means none.
+	ActionNone Action = iota
+
+	// ActionUpdate is the action that means update the log group.
+	ActionUpdate
+
+	// ActionDelete is the action that means delete the log group.
+	ActionDelete
+
+	// ActionSkip is the action that means skip the log group.
+	ActionSkip
+
+	// ActionNoop is the action that means the log group is already in the desired state.
+	ActionNoop
+)
+
+// String returns the string representation of the Action.
+func (t Action) String() string {
+	switch t {
+	case ActionNone:
+		return "none"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	case ActionSkip:
+		return "skip"
+	case ActionNoop:
+		return "noop"
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON returns the JSON representation of the Action.
+func (t Action) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// Mode represents the direction in which the retention is allowed to change.
+type Mode int
+
+const (
+	// ModeExact is the mode that means the retention is changed to the desired state in either direction.
+	ModeExact Mode = iota
+
+	// ModeShortenOnly is the mode that means the retention is only shortened, including deletion.
+	ModeShortenOnly
+
+	// ModeLengthenOnly is the mode that means the retention is only lengthened.
+	ModeLengthenOnly
+)
+
+// String returns the string representation of the Mode.
+func (t Mode) String() string {
+	switch t {
+	case ModeExact:
+		return "exact"
+	case ModeShortenOnly:
+		return "shorten-only"
+	case ModeLengthenOnly:
+		return "lengthen-only"
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON returns the JSON representation of the Mode.
+func (t Mode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON parses the JSON representation of the Mode.
+func (t *Mode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	m, err := ParseMode(s)
+	if err != nil {
+		return err
+	}
+	*t = m
+	return nil
+}
+
+// ParseMode parses a string into a Mode.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case ModeExact.String():
+		return ModeExact, nil
+	case ModeShortenOnly.String():
+		return ModeShortenOnly, nil
+	case ModeLengthenOnly.String():
+		return ModeLengthenOnly, nil
+	default:
+		return ModeExact, fmt.Errorf("unsupported mode: %q", s)
+	}
+}
+
+// Round represents the direction to snap the retention that is not allowed to the allowed one.
+type Round int
+
+const (
+	// RoundNone is the round that means the retention that is not allowed is rejected.
+	RoundNone Round = iota
+
+	// RoundUp is the round that means the retention is snapped to the nearest longer one.
+	RoundUp
+
+	// RoundDown is the round that means the retention is snapped to the nearest shorter one.
+	RoundDown
+)
+
+// String returns the string representation of the Round.
+func (t Round) String() string {
+	switch t {
+	case RoundNone:
+		return "none"
+	case RoundUp:
+		return "up"
+	case RoundDown:
+		return "down"
+	default:
+		return ""
+	}
+}
+
+// ParseRound parses a string into a Round.
+func ParseRound(s string) (Round, error) {
+	switch s {
+	case RoundNone.String():
+		return RoundNone, nil
+	case RoundUp.String():
+		return RoundUp, nil
+	case RoundDown.String():
+		return RoundDown, nil
+	default:
+		return RoundNone, fmt.Errorf("unsupported round: %q", s)
 	}
 }
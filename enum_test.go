@@ -1,6 +1,7 @@
 package llcm
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -56,6 +57,11 @@ func TestOutputType_String(t *testing.T) {
 			tr:   OutputTypeChart,
 			want: "chart",
 		},
+		{
+			name: "jsonl",
+			tr:   OutputTypeJSONL,
+			want: "jsonl",
+		},
 		{
 			name: "unknown",
 			tr:   OutputType(12345),
@@ -123,6 +129,11 @@ func TestOutputType_MarshalJSON(t *testing.T) {
 			tr:   OutputTypeChart,
 			want: []byte(`"chart"`),
 		},
+		{
+			name: "jsonl",
+			tr:   OutputTypeJSONL,
+			want: []byte(`"jsonl"`),
+		},
 		{
 			name: "unknown",
 			tr:   OutputType(12345),
@@ -217,6 +228,14 @@ func TestParseOutputType(t *testing.T) {
 			want:    OutputTypeChart,
 			wantErr: false,
 		},
+		{
+			name: "jsonl",
+			args: args{
+				s: "jsonl",
+			},
+			want:    OutputTypeJSONL,
+			wantErr: false,
+		},
 		{
 			name: "unknown",
 			args: args{
@@ -581,6 +600,71 @@ func TestDesiredState_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDesiredState_UnmarshalJSON(t *testing.T) {
+	type args struct {
+		b []byte
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    DesiredState
+		wantErr bool
+	}{
+		{
+			name: "delete",
+			args: args{
+				b: []byte(`"delete"`),
+			},
+			want:    DesiredStateZero,
+			wantErr: false,
+		},
+		{
+			name: "1month",
+			args: args{
+				b: []byte(`"1month"`),
+			},
+			want:    DesiredStateOneMonth,
+			wantErr: false,
+		},
+		{
+			name: "protect",
+			args: args{
+				b: []byte(`"protect"`),
+			},
+			want:    DesiredStateProtected,
+			wantErr: false,
+		},
+		{
+			name: "unknown",
+			args: args{
+				b: []byte(`"unknown"`),
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "not a string",
+			args: args{
+				b: []byte(`30`),
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DesiredStateNone
+			if err := got.UnmarshalJSON(tt.args.b); (err != nil) != tt.wantErr {
+				t.Errorf("DesiredState.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DesiredState.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseDesiredState(t *testing.T) {
 	type args struct {
 		s string
@@ -799,6 +883,118 @@ func TestParseDesiredState(t *testing.T) {
 			want:    DesiredStateUnprotected,
 			wantErr: false,
 		},
+		{
+			name: "day count",
+			args: args{
+				s: "400",
+			},
+			want:    DesiredStateThirteenMonths,
+			wantErr: false,
+		},
+		{
+			name: "day count with unit",
+			args: args{
+				s: "90days",
+			},
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name: "day count with short unit",
+			args: args{
+				s: "400d",
+			},
+			want:    DesiredStateThirteenMonths,
+			wantErr: false,
+		},
+		{
+			name: "day count not allowed",
+			args: args{
+				s: "100",
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "zero day count",
+			args: args{
+				s: "0",
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "negative day count",
+			args: args{
+				s: "-1",
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "iso 8601 days",
+			args: args{
+				s: "P90D",
+			},
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name: "iso 8601 weeks",
+			args: args{
+				s: "P2W",
+			},
+			want:    DesiredStateTwoWeeks,
+			wantErr: false,
+		},
+		{
+			name: "iso 8601 months",
+			args: args{
+				s: "P13M",
+			},
+			want:    DesiredStateThirteenMonths,
+			wantErr: false,
+		},
+		{
+			name: "iso 8601 years",
+			args: args{
+				s: "P5Y",
+			},
+			want:    DesiredStateFiveYears,
+			wantErr: false,
+		},
+		{
+			name: "iso 8601 hours",
+			args: args{
+				s: "PT72H",
+			},
+			want:    DesiredStateThreeDays,
+			wantErr: false,
+		},
+		{
+			name: "iso 8601 empty",
+			args: args{
+				s: "P",
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "go duration",
+			args: args{
+				s: "2160h",
+			},
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name: "go duration not whole day",
+			args: args{
+				s: "36h",
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
 		{
 			name: "none",
 			args: args{
@@ -829,3 +1025,371 @@ func TestParseDesiredState(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDesiredStateWithRound(t *testing.T) {
+	type args struct {
+		s     string
+		round Round
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    DesiredState
+		wantErr bool
+	}{
+		{
+			name: "allowed",
+			args: args{
+				s:     "90",
+				round: RoundUp,
+			},
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name: "round up",
+			args: args{
+				s:     "100",
+				round: RoundUp,
+			},
+			want:    DesiredStateFourMonths,
+			wantErr: false,
+		},
+		{
+			name: "round down",
+			args: args{
+				s:     "100",
+				round: RoundDown,
+			},
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name: "round up iso 8601",
+			args: args{
+				s:     "P12M",
+				round: RoundUp,
+			},
+			want:    DesiredStateOneYear,
+			wantErr: false,
+		},
+		{
+			name: "round down go duration",
+			args: args{
+				s:     "36h",
+				round: RoundDown,
+			},
+			want:    DesiredStateOneDay,
+			wantErr: false,
+		},
+		{
+			name: "round up beyond the longest",
+			args: args{
+				s:     "4000",
+				round: RoundUp,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "round down below the shortest",
+			args: args{
+				s:     "12h",
+				round: RoundDown,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "round none",
+			args: args{
+				s:     "100",
+				round: RoundNone,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "name is not rounded",
+			args: args{
+				s:     "delete",
+				round: RoundUp,
+			},
+			want:    DesiredStateZero,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDesiredStateWithRound(tt.args.s, tt.args.round)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDesiredStateWithRound() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseDesiredStateWithRound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAction_String(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   Action
+		want string
+	}{
+		{
+			name: "none",
+			tr:   ActionNone,
+			want: "none",
+		},
+		{
+			name: "update",
+			tr:   ActionUpdate,
+			want: "update",
+		},
+		{
+			name: "delete",
+			tr:   ActionDelete,
+			want: "delete",
+		},
+		{
+			name: "skip",
+			tr:   ActionSkip,
+			want: "skip",
+		},
+		{
+			name: "noop",
+			tr:   ActionNoop,
+			want: "noop",
+		},
+		{
+			name: "unknown",
+			tr:   Action(100),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.String(); got != tt.want {
+				t.Errorf("Action.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAction_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		tr      Action
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name:    "update",
+			tr:      ActionUpdate,
+			want:    []byte(`"update"`),
+			wantErr: false,
+		},
+		{
+			name:    "skip",
+			tr:      ActionSkip,
+			want:    []byte(`"skip"`),
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			tr:      Action(100),
+			want:    []byte(`""`),
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.tr.MarshalJSON()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Action.MarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Action.MarshalJSON() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMode_String(t *testing.T) {
+	tests := []struct {
+		name string
+		tr   Mode
+		want string
+	}{
+		{
+			name: "exact",
+			tr:   ModeExact,
+			want: "exact",
+		},
+		{
+			name: "shorten only",
+			tr:   ModeShortenOnly,
+			want: "shorten-only",
+		},
+		{
+			name: "lengthen only",
+			tr:   ModeLengthenOnly,
+			want: "lengthen-only",
+		},
+		{
+			name: "unknown",
+			tr:   Mode(100),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tr.String(); got != tt.want {
+				t.Errorf("Mode.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMode_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		want    Mode
+		wantErr bool
+	}{
+		{
+			name:    "shorten only",
+			b:       []byte(`"shorten-only"`),
+			want:    ModeShortenOnly,
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			b:       []byte(`"unknown"`),
+			want:    ModeExact,
+			wantErr: true,
+		},
+		{
+			name:    "not string",
+			b:       []byte(`1`),
+			want:    ModeExact,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Mode
+			if err := got.UnmarshalJSON(tt.b); (err != nil) != tt.wantErr {
+				t.Errorf("Mode.UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Mode.UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := `"` + tt.want.String() + `"`; string(b) != want {
+				t.Errorf("Mode.MarshalJSON() = %s, want %s", b, want)
+			}
+		})
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Mode
+		wantErr bool
+	}{
+		{
+			name:    "exact",
+			s:       "exact",
+			want:    ModeExact,
+			wantErr: false,
+		},
+		{
+			name:    "shorten only",
+			s:       "shorten-only",
+			want:    ModeShortenOnly,
+			wantErr: false,
+		},
+		{
+			name:    "lengthen only",
+			s:       "lengthen-only",
+			want:    ModeLengthenOnly,
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			s:       "",
+			want:    ModeExact,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMode(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRound(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Round
+		wantErr bool
+	}{
+		{
+			name:    "none",
+			s:       "none",
+			want:    RoundNone,
+			wantErr: false,
+		},
+		{
+			name:    "up",
+			s:       "up",
+			want:    RoundUp,
+			wantErr: false,
+		},
+		{
+			name:    "down",
+			s:       "down",
+			want:    RoundDown,
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			s:       "nearest",
+			want:    RoundNone,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRound(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRound() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s {
+				t.Errorf("Round.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package llcm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	_ Entry                  = (*EntryError)(nil)
+	_ EntryData[*EntryError] = (Errors)(nil)
+)
+
+// TotalErrorsLabel is the label of the total number of errors.
+var TotalErrorsLabel = "errors"
+
+// errorsHeader is the header of Errors.
+var errorsHeader = []string{
+	"AccountID",
+	"Region",
+	"Name",
+	"Error",
+}
+
+// EntryError represents an error that occurred for a region or a log group.
+type EntryError struct {
+	AccountID    string // The account ID where the error occurred.
+	Region       string // The region where the error occurred.
+	LogGroupName string // The name of the log group, empty if the error occurred for the whole region.
+	Message      string // The error message.
+
+	err error
+}
+
+// newEntryError creates a new error for the region, or for the log group if the entry is specified.
+func newEntryError(accountID, region string, e *entry, err error) *EntryError {
+	ee := &EntryError{
+		AccountID: accountID,
+		Region:    region,
+		Message:   err.Error(),
+		err:       err,
+	}
+	if e != nil {
+		ee.AccountID = e.AccountID
+		ee.LogGroupName = e.LogGroupName
+	}
+	return ee
+}
+
+// Error returns the error message with the account, region and log group where it occurred.
+func (e *EntryError) Error() string {
+	var b strings.Builder
+	if e.AccountID != "" {
+		b.WriteString(e.AccountID + ": ")
+	}
+	b.WriteString(e.Region + ": ")
+	if e.LogGroupName != "" {
+		b.WriteString(e.LogGroupName + ": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *EntryError) Unwrap() error {
+	return e.err
+}
+
+// Name returns the name of the log group, or the region if the error occurred for the whole region.
+func (e *EntryError) Name() string {
+	if e.LogGroupName == "" {
+		return e.Region
+	}
+	return e.LogGroupName
+}
+
+// account returns the account ID where the error occurred.
+func (e *EntryError) account() string {
+	return e.AccountID
+}
+
+// DataSet returns map for plotting the chart.
+func (e *EntryError) DataSet() map[string]int64 {
+	return map[string]int64{}
+}
+
+// toInput returns the input of the error for rendering.
+func (e *EntryError) toInput() []any {
+	return []any{
+		e.AccountID,
+		e.Region,
+		e.LogGroupName,
+		e.Message,
+	}
+}
+
+// toTSV returns the tab-separated values of the error for rendering.
+func (e *EntryError) toTSV() []string {
+	return []string{
+		e.AccountID,
+		e.Region,
+		e.LogGroupName,
+		e.Message,
+	}
+}
+
+// Errors represents the errors collected per region and per log group when continuing on error.
+// It can be rendered as an error summary table.
+type Errors []*EntryError
+
+// Error returns the number of errors and the first one.
+func (e Errors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no errors"
+	case 1:
+		return e[0].Error()
+	default:
+		return fmt.Sprintf("%d errors occurred, first: %s", len(e), e[0].Error())
+	}
+}
+
+// Unwrap returns the collected errors.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ee := range e {
+		errs[i] = ee
+	}
+	return errs
+}
+
+// Header returns the header of the Errors.
+func (e Errors) Header() []string {
+	return errorsHeader
+}
+
+// Entries returns the entries of the Errors.
+func (e Errors) Entries() []*EntryError {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Total returns the total of the Errors.
+func (e Errors) Total() map[string]int64 {
+	return map[string]int64{
+		TotalErrorsLabel: int64(len(e)),
+	}
+}
+
+// TotalByAccount returns the total of the Errors for each account.
+func (e Errors) TotalByAccount() map[string]map[string]int64 {
+	m := make(map[string]map[string]int64)
+	for _, ee := range e {
+		if _, ok := m[ee.AccountID]; !ok {
+			m[ee.AccountID] = map[string]int64{
+				TotalErrorsLabel: 0,
+			}
+		}
+		m[ee.AccountID][TotalErrorsLabel]++
+	}
+	return m
+}
+
+// Chart does nothing because the errors are not plotted.
+func (e Errors) Chart() error {
+	return nil
+}
+
+// sort sorts the errors by account, region and log group name.
+func (e Errors) sort() {
+	slices.SortFunc(e, func(a, b *EntryError) int {
+		return cmp.Or(
+			cmp.Compare(a.AccountID, b.AccountID),
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.LogGroupName, b.LogGroupName),
+		)
+	})
+}
+
+// isErrors reports whether the error is the errors collected when continuing on error.
+func isErrors(err error) bool {
+	var errs Errors
+	return errors.As(err, &errs)
+}
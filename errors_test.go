@@ -0,0 +1,165 @@
+package llcm
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEntryError_Error(t *testing.T) {
+	err := errors.New("error")
+	tests := []struct {
+		name string
+		e    *EntryError
+		want string
+	}{
+		{
+			name: "log group",
+			e:    newEntryError("", "us-east-1", &entry{LogGroupName: "group0", AccountID: "123456789012"}, err),
+			want: "123456789012: us-east-1: group0: error",
+		},
+		{
+			name: "region",
+			e:    newEntryError("123456789012", "us-east-1", nil, err),
+			want: "123456789012: us-east-1: error",
+		},
+		{
+			name: "default account",
+			e:    newEntryError("", "us-east-1", nil, err),
+			want: "us-east-1: error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.Error(); got != tt.want {
+				t.Errorf("EntryError.Error() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(tt.e, err) {
+				t.Errorf("EntryError.Unwrap() does not return the underlying error")
+			}
+		})
+	}
+}
+
+func TestErrors_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		e    Errors
+		want string
+	}{
+		{
+			name: "empty",
+			e:    Errors{},
+			want: "no errors",
+		},
+		{
+			name: "single",
+			e: Errors{
+				newEntryError("", "us-east-1", nil, errors.New("error")),
+			},
+			want: "us-east-1: error",
+		},
+		{
+			name: "multiple",
+			e: Errors{
+				newEntryError("", "us-east-1", nil, errors.New("error1")),
+				newEntryError("", "us-west-2", nil, errors.New("error2")),
+			},
+			want: "2 errors occurred, first: us-east-1: error1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.e.Error(); got != tt.want {
+				t.Errorf("Errors.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrors_TotalByAccount(t *testing.T) {
+	e := Errors{
+		newEntryError("123456789012", "us-east-1", nil, errors.New("error")),
+		newEntryError("123456789012", "us-west-2", nil, errors.New("error")),
+		newEntryError("210987654321", "us-east-1", nil, errors.New("error")),
+	}
+	want := map[string]map[string]int64{
+		"123456789012": {
+			TotalErrorsLabel: 2,
+		},
+		"210987654321": {
+			TotalErrorsLabel: 1,
+		},
+	}
+	if got := e.TotalByAccount(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Errors.TotalByAccount() = %v, want %v", got, want)
+	}
+	if got := e.Total(); !reflect.DeepEqual(got, map[string]int64{TotalErrorsLabel: 3}) {
+		t.Errorf("Errors.Total() = %v, want %v", got, 3)
+	}
+}
+
+func TestErrors_sort(t *testing.T) {
+	e := Errors{
+		newEntryError("", "us-west-2", &entry{LogGroupName: "group1", AccountID: "210987654321"}, errors.New("error")),
+		newEntryError("", "us-west-2", &entry{LogGroupName: "group0", AccountID: "210987654321"}, errors.New("error")),
+		newEntryError("123456789012", "us-west-2", nil, errors.New("error")),
+		newEntryError("123456789012", "us-east-1", nil, errors.New("error")),
+	}
+	e.sort()
+	want := []string{"us-east-1", "us-west-2", "group0", "group1"}
+	for i, ee := range e {
+		if got := ee.Name(); got != want[i] {
+			t.Errorf("Errors.sort() [%d] = %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestErrors_Render(t *testing.T) {
+	e := Errors{
+		newEntryError("123456789012", "us-east-1", nil, errors.New("access denied")),
+		newEntryError("", "us-west-2", &entry{LogGroupName: "group0", AccountID: "123456789012"}, errors.New("error")),
+	}
+	tests := []struct {
+		name       string
+		outputType OutputType
+		want       string
+	}{
+		{
+			name:       "tsv",
+			outputType: OutputTypeTSV,
+			want: `AccountID	Region	Name	Error
+123456789012	us-east-1		access denied
+123456789012	us-west-2	group0	error
+`,
+		},
+		{
+			name:       "json",
+			outputType: OutputTypeJSON,
+			want: `[{"AccountID":"123456789012","Region":"us-east-1","LogGroupName":"","Message":"access denied"},{"AccountID":"123456789012","Region":"us-west-2","LogGroupName":"group0","Message":"error"}]
+`,
+		},
+		{
+			name:       "chart",
+			outputType: OutputTypeChart,
+			want:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			ren := &Renderer[*EntryError, Errors]{
+				Data:       e,
+				OutputType: tt.outputType,
+				w:          w,
+			}
+			if err := ren.Render(); err != nil {
+				t.Fatal(err)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Renderer.Render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
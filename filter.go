@@ -0,0 +1,202 @@
+package llcm
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nekrassov01/filter"
+)
+
+// timeKeys is the list of the filter keys of time, whose date-only literals are taken as the midnight in UTC.
+var timeKeys = []string{
+	"created", "Created", "createdAt", "CreatedAt",
+	"lastEvent", "LastEvent", "lastEventAt", "LastEventAt",
+}
+
+// unitLiteralPattern is the pattern of a literal with a unit, e.g. 10GB, 500MiB and 90d.
+var unitLiteralPattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)([A-Za-z]+)$`)
+
+// sizeUnits is the map of the size units in lower case to the number of bytes.
+var sizeUnits = map[string]float64{
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// dayUnits is the map of the duration units to the number of days.
+var dayUnits = map[string]int64{
+	"d": 1,
+	"w": 7,
+	"y": 365,
+}
+
+// parseFilter parses the raw filter string into the expressions.
+// The literals with units and the keyword infinite are rewritten into numbers before parsing.
+func parseFilter(raw string) (*filterExpr, error) {
+	s, err := rewriteFilter(raw)
+	if err != nil {
+		return nil, err
+	}
+	return filter.Parse(s)
+}
+
+// findResultKey returns the first filter key in the raw filter string that refers to the simulated results,
+// which cannot be evaluated before the desired state is resolved. It reports false if there is none.
+func findResultKey(raw string) (string, bool) {
+	for _, tok := range tokenizeFilter(raw) {
+		if slices.Contains(resultKeys, tok) {
+			return tok, true
+		}
+	}
+	return "", false
+}
+
+// rewriteFilter rewrites the unquoted literals with units and the keyword infinite in the raw filter string into numbers,
+// and the tag keys into the identifiers.
+// The quoted strings are left as they are, except that a date-only string compared with a time key is taken as
+// the midnight in UTC of the date, since the filter accepts only RFC3339 for time.
+// The error points at the line and column of the offending literal.
+func rewriteFilter(raw string) (string, error) {
+	var (
+		b         strings.Builder
+		rs        = []rune(raw)
+		line, col = 1, 1
+		key       string // The last identifier, cleared by any token other than the comparison operators.
+		compared  bool   // Whether a comparison operator follows the key.
+	)
+	advance := func(s []rune) {
+		for _, r := range s {
+			if r == '\n' {
+				line++
+				col = 1
+				continue
+			}
+			col++
+		}
+	}
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch r := rs[i]; {
+		case isQuote(r):
+			j = scanQuoted(rs, i)
+			tok := string(rs[i:j])
+			if compared && slices.Contains(timeKeys, key) {
+				tok = rewriteDate(tok)
+			}
+			b.WriteString(tok)
+			key, compared = "", false
+		case isLiteralRune(r):
+			for j < len(rs) && isLiteralRune(rs[j]) {
+				j++
+			}
+			tok := string(rs[i:j])
+			s, err := rewriteLiteral(tok)
+			if err != nil {
+				return "", fmt.Errorf("invalid literal at %d:%d: %q: %w", line, col, tok, err)
+			}
+			b.WriteString(s)
+			key, compared = tok, false
+		default:
+			switch {
+			case strings.ContainsRune("=!<>", r):
+				compared = key != ""
+			case !unicode.IsSpace(r):
+				key, compared = "", false
+			}
+			b.WriteRune(r)
+		}
+		advance(rs[i:j])
+		i = j
+	}
+	return b.String(), nil
+}
+
+// rewriteDate rewrites the quoted date-only string into the midnight in UTC of the date in RFC3339.
+// The other strings are returned as they are.
+func rewriteDate(tok string) string {
+	rs := []rune(tok)
+	if len(rs) < 2 || rs[0] != rs[len(rs)-1] {
+		return tok
+	}
+	s := string(rs[1 : len(rs)-1])
+	if _, err := time.Parse(time.DateOnly, s); err != nil {
+		return tok
+	}
+	return fmt.Sprintf("%c%sT00:00:00Z%c", rs[0], s, rs[0])
+}
+
+// isQuote reports whether the rune starts a quoted string.
+func isQuote(r rune) bool {
+	return r == '"' || r == '\'' || r == '`'
+}
+
+// scanQuoted returns the index next to the end of the quoted string that starts at i.
+// The escape sequences are skipped except in the raw string quoted with backticks.
+func scanQuoted(rs []rune, i int) int {
+	q, j := rs[i], i+1
+	for j < len(rs) && rs[j] != q {
+		if rs[j] == '\\' && q != '`' {
+			j++
+		}
+		j++
+	}
+	return min(j+1, len(rs))
+}
+
+// isLiteralRune reports whether the rune can be part of an unquoted identifier or literal.
+func isLiteralRune(r rune) bool {
+	return !unicode.IsSpace(r) && !isQuote(r) && !strings.ContainsRune("()!=<>&|~*", r)
+}
+
+// rewriteLiteral rewrites the literal with a size unit into bytes, with a duration unit into days,
+// the keyword infinite into the retention days that mean never expire, and the tag key into the identifier.
+// The other identifiers and literals, including the Go durations, are returned as they are.
+func rewriteLiteral(tok string) (string, error) {
+	if tok == DesiredStateInfinite.String() {
+		return strconv.Itoa(int(DesiredStateInfinite)), nil
+	}
+	if key, ok := strings.CutPrefix(tok, tagKeyPrefix); ok && key != "" {
+		return tagIdent(key), nil
+	}
+	m := unitLiteralPattern.FindStringSubmatch(tok)
+	if m == nil {
+		return tok, nil
+	}
+	n, unit := m[1], m[2]
+	if size, ok := sizeUnits[strings.ToLower(unit)]; ok {
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return "", err
+		}
+		v := f * size
+		if v != math.Trunc(v) {
+			return "", fmt.Errorf("%s is not a whole number of bytes", strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		return strconv.FormatInt(int64(v), 10), nil
+	}
+	if days, ok := dayUnits[unit]; ok {
+		v, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%s is not a whole number of %s", n, unit)
+		}
+		return strconv.FormatInt(v*days, 10), nil
+	}
+	if _, err := time.ParseDuration(tok); err == nil {
+		return tok, nil
+	}
+	return "", fmt.Errorf("unknown unit: %q", unit)
+}
@@ -0,0 +1,254 @@
+package llcm
+
+import (
+	"testing"
+)
+
+func TestParseFilter(t *testing.T) {
+	e := &entry{
+		LogGroupName:    "2022-01-01",
+		Region:          "eu-west-1",
+		CreatedAt:       mustTime("2021-12-31T23:59:59Z"),
+		LastEventAt:     mustTime("2024-12-31T12:00:00Z"),
+		ElapsedDays:     400,
+		StoredBytes:     20 << 30,
+		RetentionInDays: 9999,
+		Tags:            map[string]string{"env": "prod", "aws:cloudformation:stack-name": "stack"},
+	}
+	tests := []struct {
+		name    string
+		raw     string
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:    "date literal",
+			raw:     `createdAt < "2022-01-01"`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "date literal in single quotes",
+			raw:     `Created >= '2022-01-01'`,
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "date literal for last event",
+			raw:     `lastEventAt < "2025-01-01" && LastEvent >= '2024-12-31'`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "rfc3339 literal",
+			raw:     `createdAt == "2021-12-31T23:59:59Z"`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "date literal for other keys",
+			raw:     `region =~ "^eu-" && name == "2022-01-01"`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "size literals",
+			raw:     `bytes > 10GB && bytes < 21.5GiB`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "duration literals and infinite",
+			raw:     `elapsed >= 1y && elapsed < 60w && retention == infinite`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "unit literal in quotes",
+			raw:     `name == "10GB"`,
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name:    "tag keys",
+			raw:     `tag.env == "prod" && tag.aws:cloudformation:stack-name =~ "^st" && tag.owner == ""`,
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:    "unknown unit",
+			raw:     `bytes > 10XB`,
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name:    "invalid",
+			raw:     `createdAt <`,
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := parseFilter(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			got, err := expr.Eval(e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("parseFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "decimal size",
+			raw:     `bytes > 10GB`,
+			want:    `bytes > 10000000000`,
+			wantErr: false,
+		},
+		{
+			name:    "binary size",
+			raw:     `bytes >= 500MiB`,
+			want:    `bytes >= 524288000`,
+			wantErr: false,
+		},
+		{
+			name:    "fractional size",
+			raw:     `bytes < 1.5kb`,
+			want:    `bytes < 1500`,
+			wantErr: false,
+		},
+		{
+			name:    "durations",
+			raw:     `elapsed > 1y || retention <= 90d || reductionInDays > 2w`,
+			want:    `elapsed > 365 || retention <= 90 || reductionInDays > 14`,
+			wantErr: false,
+		},
+		{
+			name:    "infinite",
+			raw:     `retention==infinite`,
+			want:    `retention==9999`,
+			wantErr: false,
+		},
+		{
+			name:    "quoted",
+			raw:     `name == "10GB" || name == 'infinite'`,
+			want:    `name == "10GB" || name == 'infinite'`,
+			wantErr: false,
+		},
+		{
+			name:    "raw string",
+			raw:     "name == `10GB\\` || bytes > 1KiB",
+			want:    "name == `10GB\\` || bytes > 1024",
+			wantErr: false,
+		},
+		{
+			name:    "go duration",
+			raw:     `elapsed > 1500ms`,
+			want:    `elapsed > 1500ms`,
+			wantErr: false,
+		},
+		{
+			name:    "rfc3339",
+			raw:     `createdAt < 2022-01-01T00:00:00Z`,
+			want:    `createdAt < 2022-01-01T00:00:00Z`,
+			wantErr: false,
+		},
+		{
+			name:    "date",
+			raw:     `createdAt < "2022-01-01" && Created>='2021-01-01'`,
+			want:    `createdAt < "2022-01-01T00:00:00Z" && Created>='2021-01-01T00:00:00Z'`,
+			wantErr: false,
+		},
+		{
+			name:    "date of last event",
+			raw:     `lastEventAt < "2025-01-01" || lastEvent>="2024-01-01"`,
+			want:    `lastEventAt < "2025-01-01T00:00:00Z" || lastEvent>="2024-01-01T00:00:00Z"`,
+			wantErr: false,
+		},
+		{
+			name:    "date in quoted string",
+			raw:     `name == "createdAt < '2022-01-01'"`,
+			want:    `name == "createdAt < '2022-01-01'"`,
+			wantErr: false,
+		},
+		{
+			name:    "date in mismatched quotes",
+			raw:     `createdAt < "2022-01-01'`,
+			want:    `createdAt < "2022-01-01'`,
+			wantErr: false,
+		},
+		{
+			name:    "date matched by regexp",
+			raw:     `created =~ "2022-01-01"`,
+			want:    `created =~ "2022-01-01"`,
+			wantErr: false,
+		},
+		{
+			name:    "date for other keys",
+			raw:     `name == "2022-01-01" || region == '2022-01-01'`,
+			want:    `name == "2022-01-01" || region == '2022-01-01'`,
+			wantErr: false,
+		},
+		{
+			name:    "tag key",
+			raw:     `tag.env == "prod" || name == "tag.env"`,
+			want:    `tag_656e76 == "prod" || name == "tag.env"`,
+			wantErr: false,
+		},
+		{
+			name:    "unknown unit",
+			raw:     "name == \"a\" &&\n bytes > 10XB",
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "fractional bytes",
+			raw:     `bytes > 1.5B`,
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "fractional days",
+			raw:     `elapsed > 1.5d`,
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rewriteFilter(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("rewriteFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("rewriteFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRewriteFilter_position(t *testing.T) {
+	_, err := rewriteFilter("name == \"a\" &&\n  bytes > 10XB")
+	want := `invalid literal at 2:11: "10XB": unknown unit: "XB"`
+	if err == nil || err.Error() != want {
+		t.Errorf("rewriteFilter() error = %v, want %v", err, want)
+	}
+}
@@ -2,108 +2,249 @@ package llcm
 
 import (
 	"context"
+	"iter"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 )
 
-var entriesSize = 1024
+// entriesSize is the initial capacity of the entries.
+const entriesSize = 1024
 
-// handle enumerates log groups for all regions to get targets for the process.
+// handle enumerates log groups for all account and region pairs to get targets for the process.
 // For each entry, the specified handler is executed.
+// By default, it stops at the first error. If continuing on error, the errors are collected
+// per region and per log group, and returned as Errors after all the remaining work is done.
 func (man *Manager) handle(ctx context.Context, handleFunc func(*entry) error) error {
-	var wg sync.WaitGroup
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs Errors
+	)
 	ctx, cancel := context.WithCancel(ctx)
 	errorChan := make(chan error, 1)
+	fetchTags := man.fetchTags()
+	fetchIdle := man.fetchIdle()
+	fetchOrphan := man.fetchOrphan()
 	defer cancel()
-	errorFunc := func(err error) {
+	errorFunc := func(err *EntryError) {
+		if man.continueOnError {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+			return
+		}
 		select {
-		case errorChan <- err:
+		case errorChan <- err.err:
 			cancel()
 		default:
 		}
 	}
-	for _, region := range man.regions {
-		wg.Go(func() {
-			opt := func(o *cloudwatchlogs.Options) {
-				o.Region = region
-			}
-			in := &cloudwatchlogs.DescribeLogGroupsInput{
-				NextToken: nil,
-			}
-			for {
-				out, err := man.client.DescribeLogGroups(ctx, in, opt)
-				if err != nil {
-					errorFunc(err)
-					return
+	for _, client := range man.clients() {
+		for _, region := range man.regions {
+			wg.Go(func() {
+				key := limitKey{accountID: client.accountID, region: region, api: apiDescribeLogGroups}
+				in := &cloudwatchlogs.DescribeLogGroupsInput{
+					NextToken: nil,
 				}
-				for _, logGroup := range out.LogGroups {
-					if err := man.sem.Acquire(ctx, 1); err != nil {
-						errorFunc(err)
+				man.pushdown.apply(in)
+				man.debug("describing log groups", "account", client.accountID, "region", region, "pushdown", man.pushdown)
+				for {
+					var out *cloudwatchlogs.DescribeLogGroupsOutput
+					err := man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) (err error) {
+						out, err = client.DescribeLogGroups(ctx, in, opt)
+						return err
+					})
+					if err != nil {
+						errorFunc(newEntryError(client.accountID, region, nil, err))
 						return
 					}
-					wg.Go(func() {
-						defer man.sem.Release(1)
-						entry := newEntry(logGroup, region)
-						if man.filterExpr != nil {
-							ok, err := man.filterExpr.Eval(entry)
-							if err != nil {
-								errorFunc(err)
-								return
+					for _, logGroup := range out.LogGroups {
+						if err := man.sem.Acquire(ctx, 1); err != nil {
+							errorFunc(newEntryError(client.accountID, region, nil, err))
+							return
+						}
+						wg.Go(func() {
+							defer man.sem.Release(1)
+							now := man.now()
+							entry := newEntry(logGroup, region, client, now)
+							entry.tagColumns = man.tagColumns
+							if fetchTags {
+								if err := man.listTags(ctx, entry); err != nil {
+									errorFunc(newEntryError(client.accountID, region, entry, err))
+									return
+								}
+							}
+							if fetchIdle {
+								if err := man.describeLastEvent(ctx, entry, now); err != nil {
+									errorFunc(newEntryError(client.accountID, region, entry, err))
+									return
+								}
+							}
+							if fetchOrphan {
+								if err := man.checkOrphan(ctx, entry); err != nil {
+									errorFunc(newEntryError(client.accountID, region, entry, err))
+									return
+								}
 							}
-							if !ok {
+							if man.filterExpr != nil {
+								ok, err := man.filterExpr.Eval(entry)
+								if err != nil {
+									errorFunc(newEntryError(client.accountID, region, entry, err))
+									return
+								}
+								if !ok {
+									return
+								}
+							}
+							if err := handleFunc(entry); err != nil {
+								errorFunc(newEntryError(client.accountID, region, entry, err))
 								return
 							}
-						}
-						if err := handleFunc(entry); err != nil {
-							errorFunc(err)
-							return
-						}
-					})
-				}
-				if out.NextToken == nil {
-					break
+						})
+					}
+					if out.NextToken == nil {
+						break
+					}
+					in.NextToken = out.NextToken
 				}
-				in.NextToken = out.NextToken
-			}
-		})
+			})
+		}
 	}
 	wg.Wait()
 	close(errorChan)
-	select {
-	case err := <-errorChan:
+	if err := <-errorChan; err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
 		return err
-	case <-ctx.Done():
-		return ctx.Err()
 	}
+	if len(errs) > 0 {
+		errs.sort()
+		return errs
+	}
+	return nil
+}
+
+// stream returns the iterator that yields the entries converted by the function as the log groups are found.
+// The entries not converted are skipped. Stopping the iteration cancels the remaining work.
+// The error of the handling, including the errors collected when continuing on error, is yielded at last.
+func stream[E any](ctx context.Context, man *Manager, fn func(*entry) (E, bool, error)) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ch := make(chan E)
+		done := make(chan error, 1)
+		go func() {
+			defer close(ch)
+			done <- man.handle(ctx, func(entry *entry) error {
+				e, ok, err := fn(entry)
+				if err != nil || !ok {
+					return err
+				}
+				select {
+				case ch <- e:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			})
+		}()
+		for e := range ch {
+			if !yield(e, nil) {
+				cancel()
+				for range ch {
+				}
+				return
+			}
+		}
+		if err := <-done; err != nil {
+			var zero E
+			yield(zero, err)
+		}
+	}
+}
+
+// header returns the header followed by the extra columns: the last event and the orphaned if fetched,
+// and the tags selected.
+func (man *Manager) header(header []string) []string {
+	if man.fetchIdle() {
+		header = append(slices.Clip(header), idleHeader...)
+	}
+	if man.fetchOrphan() {
+		header = append(slices.Clip(header), orphanHeader...)
+	}
+	return tagHeader(header, man.tagColumns)
 }
 
-// newEntry creates a new entry from the log group and specified region.
-func newEntry(logGroup types.LogGroup, region string) *entry {
+// matchResult reports whether the simulated results of the entry match the result filter.
+// Without the result filter, all entries match.
+func (man *Manager) matchResult(e *PreviewEntry) (bool, error) {
+	if man.resultExpr == nil {
+		return true, nil
+	}
+	return man.resultExpr.Eval(e)
+}
+
+// newEntry creates a new entry from the log group, specified region and the client that found it.
+// The elapsed days are counted up to the specified time.
+func newEntry(logGroup types.LogGroup, region string, client *Client, now time.Time) *entry {
 	e := &entry{}
 	e.LogGroupName = aws.ToString(logGroup.LogGroupName)
+	e.AccountID = accountID(logGroup, client)
 	e.Region = region
 	e.Class = logGroup.LogGroupClass
 	e.CreatedAt = createdAt(logGroup.CreationTime)
 	e.DeletionProtection = aws.ToBool(logGroup.DeletionProtectionEnabled)
-	e.ElapsedDays = elapsedDays(e.CreatedAt)
+	e.ElapsedDays = elapsedDays(e.CreatedAt, now)
 	e.RetentionInDays = retentionInDays(logGroup.RetentionInDays)
 	e.StoredBytes = aws.ToInt64(logGroup.StoredBytes)
+	e.Arn = logGroupArn(logGroup)
+	e.KmsKeyId = aws.ToString(logGroup.KmsKeyId)
+	e.DataProtectionStatus = logGroup.DataProtectionStatus
+	e.MetricFilterCount = int64(aws.ToInt32(logGroup.MetricFilterCount))
+	e.InheritedProperties = logGroup.InheritedProperties
 	e.name = logGroup.LogGroupName
+	e.client = client
 	return e
 }
 
+// accountID returns the account ID that owns the log group.
+// It is resolved from the log group ARN and falls back to the account of the client.
+func accountID(logGroup types.LogGroup, client *Client) string {
+	for _, s := range []*string{logGroup.LogGroupArn, logGroup.Arn} {
+		if a, err := arn.Parse(aws.ToString(s)); err == nil && a.AccountID != "" {
+			return a.AccountID
+		}
+	}
+	if client != nil {
+		return client.accountID
+	}
+	return ""
+}
+
+// logGroupArn returns the ARN of the log group without the trailing wildcard.
+func logGroupArn(logGroup types.LogGroup) string {
+	if logGroup.LogGroupArn != nil {
+		return aws.ToString(logGroup.LogGroupArn)
+	}
+	return strings.TrimSuffix(aws.ToString(logGroup.Arn), ":*")
+}
+
 // createdAt returns the creation time of the log group.
 func createdAt(t *int64) time.Time {
 	return time.Unix(0, aws.ToInt64(t)*int64(time.Millisecond))
 }
 
-// elapsedDays returns the elapsed days from the creation time.
-func elapsedDays(t time.Time) int64 {
-	return int64(nowFunc().Sub(t).Hours() / 24)
+// elapsedDays returns the elapsed days from the creation time up to now.
+func elapsedDays(t, now time.Time) int64 {
+	return int64(now.Sub(t).Hours() / 24)
 }
 
 // retentionInDays returns the retention days from the log group.
@@ -2,110 +2,282 @@ package llcm
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
-	"sync/atomic"
+	"sync"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 )
 
-// Apply applies the desired state to the log groups.
-func (man *Manager) Apply(ctx context.Context, w io.Writer) (int32, error) {
-	var n atomic.Int32
+// ConfirmFunc is the function that is asked to approve the apply with the summary of the changes.
+// The apply proceeds only if it returns true.
+type ConfirmFunc func(ctx context.Context, summary *ApplySummary) (bool, error)
+
+// ApplySummary represents the summary of the changes that apply is about to make.
+type ApplySummary struct {
+	Changes      int64 // The number of log groups to be changed.
+	StoredBytes  int64 // The total stored bytes of the log groups to be changed.
+	Deletes      int64 // The number of log groups to be deleted.
+	DeletedBytes int64 // The total stored bytes of the log groups to be deleted.
+}
+
+// applyTarget represents a log group and the desired state to be applied to it.
+type applyTarget struct {
+	entry   *entry
+	desired DesiredState
+}
+
+// newApplySummary summarizes the changes for the targets in the mode and whether to force unprotecting.
+// The log groups that require no write call are not counted.
+func newApplySummary(targets []*applyTarget, mode Mode, forceUnprotect bool) *ApplySummary {
+	s := &ApplySummary{}
+	for _, t := range targets {
+		if action, _ := t.entry.action(t.desired, mode, forceUnprotect); action != ActionUpdate && action != ActionDelete {
+			continue
+		}
+		s.Changes++
+		s.StoredBytes += t.entry.StoredBytes
+		if t.desired == DesiredStateZero {
+			s.Deletes++
+			s.DeletedBytes += t.entry.StoredBytes
+		}
+	}
+	return s
+}
+
+// Apply applies the desired state to the log groups and returns the result for each log group.
+// If the plan is set, only the log groups in the plan are applied.
+// Otherwise, if the result filter is set, only the log groups whose simulated results match it are applied.
+// Nothing is changed if the limits are exceeded or the changes are not confirmed.
+func (man *Manager) Apply(ctx context.Context) (*ApplyEntryData, error) {
+	if man.plan != nil {
+		return man.applyPlan(ctx)
+	}
+	var (
+		mu      sync.Mutex
+		targets = make([]*applyTarget, 0, entriesSize)
+		errs    Errors
+	)
+	issues := &tagIssueSet{}
 	fn := func(entry *entry) error {
-		switch man.desiredState {
-		case DesiredStateNone:
-			return fmt.Errorf("invalid desired state: %q", man.desiredState)
-		case DesiredStateZero:
-			if err := man.deleteLogGroup(ctx, entry.name, entry.Region); err != nil {
-				return err
-			}
-			_, _ = fmt.Fprintf(w, "deleted log group: %s\n", entry.LogGroupName)
-		case DesiredStateInfinite:
-			if err := man.deleteRetentionPolicy(ctx, entry.name, entry.Region); err != nil {
-				return err
-			}
-			_, _ = fmt.Fprintf(w, "deleted retention policy: %s\n", entry.LogGroupName)
-		case DesiredStateProtected, DesiredStateUnprotected:
-			if err := man.putLogGroupDeletionProtection(ctx, entry.name, entry.Region); err != nil {
-				return err
-			}
-			_, _ = fmt.Fprintf(w, "%s log group: %s\n", man.desiredState.String(), entry.LogGroupName)
-		default:
-			if err := man.putRetentionPolicy(ctx, entry.name, entry.Region); err != nil {
-				return err
-			}
-			_, _ = fmt.Fprintf(w, "updated retention policy: %s\n", entry.LogGroupName)
+		e, ok, err := man.preview(entry, issues)
+		if err != nil || !ok {
+			return err
+		}
+		mu.Lock()
+		targets = append(targets, &applyTarget{entry: entry, desired: e.DesiredState})
+		mu.Unlock()
+		return nil
+	}
+	if err := man.handle(ctx, fn); err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+	if err := man.guard(ctx, newApplySummary(targets, man.mode, man.forceUnprotect)); err != nil {
+		return nil, err
+	}
+	results := make([]*ApplyEntry, len(targets))
+	err := man.each(ctx, len(targets), func(ctx context.Context, i int) error {
+		t := targets[i]
+		e, err := man.apply(ctx, t.entry, t.desired)
+		results[i] = e
+		if err != nil {
+			return newEntryError(t.entry.AccountID, t.entry.Region, t.entry, err)
 		}
-		n.Add(1)
 		return nil
+	})
+	data := &ApplyEntryData{
+		header:           man.header(applyEntryDataHeader),
+		entries:          make([]*ApplyEntry, 0, len(targets)),
+		maxPieChartItems: man.maxPieChartItems,
+		tagIssues:        issues.sorted(),
+	}
+	for _, e := range results {
+		if e != nil {
+			data.add(e)
+		}
+	}
+	var applyErrs Errors
+	if err != nil && !errors.As(err, &applyErrs) {
+		return data, err
 	}
-	err := man.handle(ctx, fn)
-	return n.Load(), err
+	errs = append(errs, applyErrs...)
+	if len(errs) > 0 {
+		errs.sort()
+		return data, errs
+	}
+	return data, nil
 }
 
-// deleteLogGroup deletes the log group.
-func (man *Manager) deleteLogGroup(ctx context.Context, name *string, region string) error {
-	opt := func(o *cloudwatchlogs.Options) {
-		o.Region = region
-		o.Retryer = retryer
+// guard checks the summary of the changes against the limits and asks for confirmation.
+// The confirmation is not required for dry run, when assumed yes, or when there is nothing to change.
+func (man *Manager) guard(ctx context.Context, s *ApplySummary) error {
+	if man.maxChanges > 0 && s.Changes > man.maxChanges {
+		return fmt.Errorf("too many changes: %d exceeds the limit of %d", s.Changes, man.maxChanges)
 	}
-	in := &cloudwatchlogs.DeleteLogGroupInput{
-		LogGroupName: name,
+	if man.maxDeletedBytes > 0 && s.DeletedBytes > man.maxDeletedBytes {
+		return fmt.Errorf("too many deleted bytes: %d exceeds the limit of %d", s.DeletedBytes, man.maxDeletedBytes)
 	}
-	_, err := man.client.DeleteLogGroup(ctx, in, opt)
+	if man.dryRun || man.assumeYes || s.Changes == 0 {
+		return nil
+	}
+	if man.confirm == nil {
+		return fmt.Errorf("confirmation required to change %d log groups with %d stored bytes", s.Changes, s.StoredBytes)
+	}
+	ok, err := man.confirm(ctx, s)
 	if err != nil {
 		return err
 	}
+	if !ok {
+		return fmt.Errorf("apply canceled")
+	}
 	return nil
 }
 
-// deleteRetentionPolicy deletes the retention policy.
-func (man *Manager) deleteRetentionPolicy(ctx context.Context, name *string, region string) error {
-	opt := func(o *cloudwatchlogs.Options) {
-		o.Region = region
-		o.Retryer = retryer
+// apply applies the desired state to the log group and returns the result.
+// If the journal is set, the prior state is recorded before the change.
+// No write call is made for the log group already in the desired state, or skipped with the reason.
+// If forced to unprotect, the protected log group is unprotected before it is deleted.
+// In dry run, the result is returned as if applied without any write call.
+// When the action fails, the error is also recorded in the result and the after state is left as before,
+// except for the protection already disabled.
+// If tagging is set, the updated log group is tagged after the change. The tagging error is recorded
+// in the result that remains successful, and is not returned so as not to stop the other log groups.
+func (man *Manager) apply(ctx context.Context, entry *entry, desired DesiredState) (*ApplyEntry, error) {
+	var (
+		e         = newApplyEntry(entry, desired)
+		start     = man.now()
+		write     func() error
+		reason    string
+		unprotect bool
+	)
+	switch e.Action, reason = entry.action(desired, man.mode, man.forceUnprotect); e.Action {
+	case ActionNoop:
+		e.Success = true
+		return e, nil
+	case ActionSkip:
+		e.Error = reason
+		return e, nil
 	}
-	in := &cloudwatchlogs.DeleteRetentionPolicyInput{
-		LogGroupName: name,
+	switch desired {
+	case DesiredStateNone:
+		err := fmt.Errorf("invalid desired state: %q", desired)
+		e.Error = err.Error()
+		return e, err
+	case DesiredStateZero:
+		e.RetentionAfter = int64(DesiredStateZero)
+		e.ProtectionAfter = false
+		unprotect = entry.DeletionProtection
+		write = func() error {
+			if unprotect {
+				if err := man.putLogGroupDeletionProtection(ctx, entry.client, entry.name, entry.Region, false); err != nil {
+					return err
+				}
+				e.Unprotected = true
+			}
+			return man.deleteLogGroup(ctx, entry.client, entry.name, entry.Region)
+		}
+	case DesiredStateInfinite:
+		e.RetentionAfter = int64(DesiredStateInfinite)
+		write = func() error {
+			return man.deleteRetentionPolicy(ctx, entry.client, entry.name, entry.Region)
+		}
+	case DesiredStateProtected, DesiredStateUnprotected:
+		e.ProtectionAfter = desired == DesiredStateProtected
+		write = func() error {
+			return man.putLogGroupDeletionProtection(ctx, entry.client, entry.name, entry.Region, e.ProtectionAfter)
+		}
+	default:
+		e.RetentionAfter = int64(desired)
+		write = func() error {
+			return man.putRetentionPolicy(ctx, entry.client, entry.name, entry.Region, int32(desired))
+		}
+	}
+	if man.dryRun {
+		e.Unprotected = unprotect
+		e.Success = true
+		e.Duration = man.now().Sub(start)
+		return e, nil
+	}
+	err := man.record(entry, desired)
+	if err == nil {
+		err = write()
 	}
-	_, err := man.client.DeleteRetentionPolicy(ctx, in, opt)
+	e.Duration = man.now().Sub(start)
 	if err != nil {
-		return err
+		e.Error = err.Error()
+		e.RetentionAfter = e.RetentionBefore
+		if !e.Unprotected {
+			e.ProtectionAfter = e.ProtectionBefore
+		}
+		return e, err
+	}
+	e.Success = true
+	if man.tagApplied && e.Action == ActionUpdate {
+		if err := man.tagResource(ctx, entry.client, entry.Arn, entry.Region, appliedTags(desired, start)); err != nil {
+			e.TagError = err.Error()
+		}
+	}
+	return e, nil
+}
+
+// record writes the prior state of the log group to the journal if it is set.
+func (man *Manager) record(entry *entry, desired DesiredState) error {
+	if man.journal == nil {
+		return nil
+	}
+	if err := man.journal.Write(newJournalEntry(entry, desired, man.now())); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
 	}
 	return nil
 }
 
-// putLogGroupDeletionProtection puts the log group deletion protection.
-func (man *Manager) putLogGroupDeletionProtection(ctx context.Context, name *string, region string) error {
-	opt := func(o *cloudwatchlogs.Options) {
-		o.Region = region
-		o.Retryer = retryer
+// deleteLogGroup deletes the log group.
+func (man *Manager) deleteLogGroup(ctx context.Context, client *Client, name *string, region string) error {
+	key := limitKey{accountID: client.accountID, region: region, api: apiDeleteLogGroup}
+	in := &cloudwatchlogs.DeleteLogGroupInput{
+		LogGroupName: name,
 	}
+	return man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) error {
+		_, err := client.DeleteLogGroup(ctx, in, opt)
+		return err
+	})
+}
+
+// deleteRetentionPolicy deletes the retention policy.
+func (man *Manager) deleteRetentionPolicy(ctx context.Context, client *Client, name *string, region string) error {
+	key := limitKey{accountID: client.accountID, region: region, api: apiDeleteRetentionPolicy}
+	in := &cloudwatchlogs.DeleteRetentionPolicyInput{
+		LogGroupName: name,
+	}
+	return man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) error {
+		_, err := client.DeleteRetentionPolicy(ctx, in, opt)
+		return err
+	})
+}
+
+// putLogGroupDeletionProtection puts the log group deletion protection.
+func (man *Manager) putLogGroupDeletionProtection(ctx context.Context, client *Client, name *string, region string, enabled bool) error {
+	key := limitKey{accountID: client.accountID, region: region, api: apiPutLogGroupDeletionProtection}
 	in := &cloudwatchlogs.PutLogGroupDeletionProtectionInput{
 		LogGroupIdentifier:        name,
-		DeletionProtectionEnabled: man.deletionProtection,
+		DeletionProtectionEnabled: aws.Bool(enabled),
 	}
-	_, err := man.client.PutLogGroupDeletionProtection(ctx, in, opt)
-	if err != nil {
+	return man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) error {
+		_, err := client.PutLogGroupDeletionProtection(ctx, in, opt)
 		return err
-	}
-	return nil
+	})
 }
 
 // putRetentionPolicy puts the retention policy.
-func (man *Manager) putRetentionPolicy(ctx context.Context, name *string, region string) error {
-	opt := func(o *cloudwatchlogs.Options) {
-		o.Region = region
-		o.Retryer = retryer
-	}
+func (man *Manager) putRetentionPolicy(ctx context.Context, client *Client, name *string, region string, days int32) error {
+	key := limitKey{accountID: client.accountID, region: region, api: apiPutRetentionPolicy}
 	in := &cloudwatchlogs.PutRetentionPolicyInput{
 		LogGroupName:    name,
-		RetentionInDays: man.desiredStateNative,
+		RetentionInDays: aws.Int32(days),
 	}
-	_, err := man.client.PutRetentionPolicy(ctx, in, opt)
-	if err != nil {
+	return man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) error {
+		_, err := client.PutRetentionPolicy(ctx, in, opt)
 		return err
-	}
-	return nil
+	})
 }
@@ -1,36 +1,39 @@
 package llcm
 
 import (
+	"bytes"
 	"context"
 	"errors"
-	"io"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"golang.org/x/sync/semaphore"
 )
 
 func TestManager_Apply(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		deletionProtection *bool
-		filterExpr         *filterExpr
-		sem                *semaphore.Weighted
+		client       *Client
+		regions      []string
+		desiredState DesiredState
+		policy       *Policy
+		dryRun       bool
+		filterExpr   *filterExpr
+		resultExpr   *filterExpr
+		sem          *semaphore.Weighted
 	}
 	type args struct {
 		ctx context.Context
-		w   io.Writer
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		want    int32
+		want    int64
 		wantErr bool
 	}{
 		{
@@ -54,15 +57,13 @@ func TestManager_Apply(t *testing.T) {
 						return out, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateNone,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateNone,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -91,15 +92,13 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateZero,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    1,
 			wantErr: false,
@@ -128,15 +127,13 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.DeleteRetentionPolicyOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateInfinite,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateInfinite,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    1,
 			wantErr: false,
@@ -155,7 +152,7 @@ func TestManager_Apply(t *testing.T) {
 									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
 									RetentionInDays:           aws.Int32(365),
 									StoredBytes:               aws.Int64(1024),
-									DeletionProtectionEnabled: aws.Bool(true),
+									DeletionProtectionEnabled: aws.Bool(false),
 								},
 							},
 						}
@@ -165,15 +162,13 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.PutLogGroupDeletionProtectionOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateProtected,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateProtected,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    1,
 			wantErr: false,
@@ -202,15 +197,13 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.PutLogGroupDeletionProtectionOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateUnprotected,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateUnprotected,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    1,
 			wantErr: false,
@@ -239,15 +232,13 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateOneDay,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateOneDay,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    1,
 			wantErr: false,
@@ -276,15 +267,13 @@ func TestManager_Apply(t *testing.T) {
 						return nil, errors.New("error")
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateZero,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -313,15 +302,13 @@ func TestManager_Apply(t *testing.T) {
 						return nil, errors.New("error")
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateInfinite,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateInfinite,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -350,15 +337,13 @@ func TestManager_Apply(t *testing.T) {
 						return nil, errors.New("error")
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateProtected,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateProtected,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -377,7 +362,7 @@ func TestManager_Apply(t *testing.T) {
 									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
 									RetentionInDays:           aws.Int32(365),
 									StoredBytes:               aws.Int64(1024),
-									DeletionProtectionEnabled: aws.Bool(false),
+									DeletionProtectionEnabled: aws.Bool(true),
 								},
 							},
 						}
@@ -387,15 +372,13 @@ func TestManager_Apply(t *testing.T) {
 						return nil, errors.New("error")
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateUnprotected,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateUnprotected,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -424,15 +407,13 @@ func TestManager_Apply(t *testing.T) {
 						return nil, errors.New("error")
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateOneDay,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateOneDay,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    0,
 			wantErr: true,
@@ -470,15 +451,142 @@ func TestManager_Apply(t *testing.T) {
 						return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
 					},
 				}),
-				regions:            []string{"us-east-1"},
-				desiredState:       DesiredStateZero,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(10),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "result filter",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:              aws.String("test-log-group-1"),
+									LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(365),
+									StoredBytes:               aws.Int64(1024),
+									DeletionProtectionEnabled: aws.Bool(false),
+								},
+								{
+									LogGroupName:              aws.String("test-log-group-2"),
+									LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(7),
+									StoredBytes:               aws.Int64(2048),
+									DeletionProtectionEnabled: aws.Bool(false),
+								},
+							},
+						}
+						return out, nil
+					},
+					DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+						return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				resultExpr:   func() *filterExpr { expr, _ := parseFilter(`reducibleBytes > 1024`); return expr }(),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(types.LogGroup{
+						LogGroupName:    aws.String("test-log-group"),
+						LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+						RetentionInDays: aws.Int32(365),
+						StoredBytes:     aws.Int64(1024),
+					}),
+					DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+						return nil, errors.New("must not be called")
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				dryRun:       true,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "policy",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:              aws.String("/aws/lambda/test-log-group"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(365),
+									StoredBytes:               aws.Int64(1024),
+									DeletionProtectionEnabled: aws.Bool(false),
+								},
+								{
+									LogGroupName:              aws.String("/ecs/test-log-group"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(365),
+									StoredBytes:               aws.Int64(1024),
+									DeletionProtectionEnabled: aws.Bool(false),
+								},
+								{
+									LogGroupName:              aws.String("test-log-group"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(365),
+									StoredBytes:               aws.Int64(1024),
+									DeletionProtectionEnabled: aws.Bool(false),
+								},
+							},
+						}
+						return out, nil
+					},
+					PutRetentionPolicyFunc: func(_ context.Context, params *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+						want := map[string]int32{
+							"/aws/lambda/test-log-group": 30,
+							"/ecs/test-log-group":        90,
+						}
+						if want[aws.ToString(params.LogGroupName)] != aws.ToInt32(params.RetentionInDays) {
+							return nil, errors.New("unexpected retention")
+						}
+						return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateNone,
+				policy:       mustPolicy("rules:\n  - filter: name =~ \"^/aws/lambda/\"\n    desired: 1month\n  - filter: name =~ \"^/ecs/\"\n    desired: 3months\n"),
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
 			},
 			args: args{
 				ctx: context.Background(),
-				w:   io.Discard,
 			},
 			want:    2,
 			wantErr: false,
@@ -487,21 +595,639 @@ func TestManager_Apply(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				deletionProtection: tt.fields.deletionProtection,
-				filterExpr:         tt.fields.filterExpr,
-				sem:                tt.fields.sem,
+				clock:        nowFunc,
+				client:       tt.fields.client,
+				regions:      tt.fields.regions,
+				desiredState: tt.fields.desiredState,
+				policy:       tt.fields.policy,
+				dryRun:       tt.fields.dryRun,
+				assumeYes:    true,
+				filterExpr:   tt.fields.filterExpr,
+				resultExpr:   tt.fields.resultExpr,
+				sem:          tt.fields.sem,
 			}
-			got, err := man.Apply(tt.args.ctx, tt.args.w)
+			got, err := man.Apply(tt.args.ctx)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Manager.Apply() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if got != tt.want {
-				t.Errorf("Manager.Apply() = %v, want %v", got, tt.want)
+			if got.TotalApplied != tt.want {
+				t.Errorf("Manager.Apply() = %v, want %v", got.TotalApplied, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_guard(t *testing.T) {
+	summary := &ApplySummary{
+		Changes:      2,
+		StoredBytes:  3072,
+		Deletes:      1,
+		DeletedBytes: 1024,
+	}
+	confirm := func(ok bool, err error) ConfirmFunc {
+		return func(_ context.Context, s *ApplySummary) (bool, error) {
+			if diff := cmp.Diff(s, summary); diff != "" {
+				return false, errors.New(diff)
+			}
+			return ok, err
+		}
+	}
+	type fields struct {
+		dryRun          bool
+		assumeYes       bool
+		confirm         ConfirmFunc
+		maxChanges      int64
+		maxDeletedBytes int64
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		summary *ApplySummary
+		wantErr bool
+	}{
+		{
+			name: "confirmed",
+			fields: fields{
+				confirm: confirm(true, nil),
+			},
+			summary: summary,
+			wantErr: false,
+		},
+		{
+			name: "canceled",
+			fields: fields{
+				confirm: confirm(false, nil),
+			},
+			summary: summary,
+			wantErr: true,
+		},
+		{
+			name: "confirm returns error",
+			fields: fields{
+				confirm: confirm(true, errors.New("error")),
+			},
+			summary: summary,
+			wantErr: true,
+		},
+		{
+			name:    "no confirmation",
+			fields:  fields{},
+			summary: summary,
+			wantErr: true,
+		},
+		{
+			name:    "no changes",
+			fields:  fields{},
+			summary: &ApplySummary{},
+			wantErr: false,
+		},
+		{
+			name: "assume yes",
+			fields: fields{
+				assumeYes: true,
+			},
+			summary: summary,
+			wantErr: false,
+		},
+		{
+			name: "dry run",
+			fields: fields{
+				dryRun: true,
+			},
+			summary: summary,
+			wantErr: false,
+		},
+		{
+			name: "within limits",
+			fields: fields{
+				assumeYes:       true,
+				maxChanges:      2,
+				maxDeletedBytes: 1024,
+			},
+			summary: summary,
+			wantErr: false,
+		},
+		{
+			name: "max changes exceeded",
+			fields: fields{
+				assumeYes:  true,
+				maxChanges: 1,
+			},
+			summary: summary,
+			wantErr: true,
+		},
+		{
+			name: "max deleted bytes exceeded in dry run",
+			fields: fields{
+				dryRun:          true,
+				maxDeletedBytes: 1023,
+			},
+			summary: summary,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:           nowFunc,
+				dryRun:          tt.fields.dryRun,
+				assumeYes:       tt.fields.assumeYes,
+				confirm:         tt.fields.confirm,
+				maxChanges:      tt.fields.maxChanges,
+				maxDeletedBytes: tt.fields.maxDeletedBytes,
+			}
+			if err := man.guard(context.Background(), tt.summary); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.guard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_apply(t *testing.T) {
+	client := newMockClient(&mockClient{
+		DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+			return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+		},
+		DeleteRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteRetentionPolicyOutput, error) {
+			return &cloudwatchlogs.DeleteRetentionPolicyOutput{}, nil
+		},
+		PutLogGroupDeletionProtectionFunc: func(_ context.Context, _ *cloudwatchlogs.PutLogGroupDeletionProtectionInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogGroupDeletionProtectionOutput, error) {
+			return &cloudwatchlogs.PutLogGroupDeletionProtectionOutput{}, nil
+		},
+		PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+			return nil, errors.New("error")
+		},
+	})
+	type args struct {
+		desired DesiredState
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *ApplyEntry
+		wantErr bool
+	}{
+		{
+			name: "delete log group",
+			args: args{
+				desired: DesiredStateZero,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionDelete,
+				RetentionBefore:  365,
+				RetentionAfter:   0,
+				ProtectionBefore: false,
+				ProtectionAfter:  false,
+				Success:          true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "delete retention policy",
+			args: args{
+				desired: DesiredStateInfinite,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateInfinite,
+				Action:           ActionUpdate,
+				RetentionBefore:  365,
+				RetentionAfter:   9999,
+				ProtectionBefore: false,
+				ProtectionAfter:  false,
+				Success:          true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "put deletion protection enabled",
+			args: args{
+				desired: DesiredStateProtected,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateProtected,
+				Action:           ActionUpdate,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: false,
+				ProtectionAfter:  true,
+				Success:          true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "put retention policy returns error",
+			args: args{
+				desired: DesiredStateOneDay,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateOneDay,
+				Action:           ActionUpdate,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: false,
+				ProtectionAfter:  false,
+				Success:          false,
+				Error:            "error",
+			},
+			wantErr: true,
+		},
+		{
+			name: "none",
+			args: args{
+				desired: DesiredStateNone,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateNone,
+				Action:           ActionNone,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: false,
+				ProtectionAfter:  false,
+				Success:          false,
+				Error:            `invalid desired state: "none"`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &entry{
+				LogGroupName:    "test-log-group",
+				Region:          "us-east-1",
+				RetentionInDays: 365,
+				name:            aws.String("test-log-group"),
+				client:          client,
+			}
+			tt.want.entry = e
+			man := &Manager{clock: nowFunc}
+			got, err := man.apply(context.Background(), e, tt.args.desired)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(ApplyEntry{}), cmpopts.IgnoreFields(ApplyEntry{}, "entry")); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestManager_applyWithForceUnprotect(t *testing.T) {
+	type fields struct {
+		forceUnprotect bool
+		dryRun         bool
+		unprotectErr   error
+		deleteErr      error
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		want      *ApplyEntry
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name: "protected",
+			fields: fields{
+				forceUnprotect: false,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionSkip,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: true,
+				ProtectionAfter:  true,
+				Error:            "log group is protected from deletion",
+			},
+			wantCalls: nil,
+			wantErr:   false,
+		},
+		{
+			name: "force unprotect",
+			fields: fields{
+				forceUnprotect: true,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionDelete,
+				RetentionBefore:  365,
+				RetentionAfter:   0,
+				ProtectionBefore: true,
+				ProtectionAfter:  false,
+				Unprotected:      true,
+				Success:          true,
+			},
+			wantCalls: []string{"unprotect", "delete"},
+			wantErr:   false,
+		},
+		{
+			name: "force unprotect in dry run",
+			fields: fields{
+				forceUnprotect: true,
+				dryRun:         true,
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionDelete,
+				RetentionBefore:  365,
+				RetentionAfter:   0,
+				ProtectionBefore: true,
+				ProtectionAfter:  false,
+				Unprotected:      true,
+				Success:          true,
+			},
+			wantCalls: nil,
+			wantErr:   false,
+		},
+		{
+			name: "unprotect returns error",
+			fields: fields{
+				forceUnprotect: true,
+				unprotectErr:   errors.New("unprotect error"),
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionDelete,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: true,
+				ProtectionAfter:  true,
+				Error:            "unprotect error",
+			},
+			wantCalls: []string{"unprotect"},
+			wantErr:   true,
+		},
+		{
+			name: "delete returns error after unprotect",
+			fields: fields{
+				forceUnprotect: true,
+				deleteErr:      errors.New("delete error"),
+			},
+			want: &ApplyEntry{
+				DesiredState:     DesiredStateZero,
+				Action:           ActionDelete,
+				RetentionBefore:  365,
+				RetentionAfter:   365,
+				ProtectionBefore: true,
+				ProtectionAfter:  false,
+				Unprotected:      true,
+				Error:            "delete error",
+			},
+			wantCalls: []string{"unprotect", "delete"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			client := newMockClient(&mockClient{
+				DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+					calls = append(calls, "delete")
+					if tt.fields.deleteErr != nil {
+						return nil, tt.fields.deleteErr
+					}
+					return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+				},
+				PutLogGroupDeletionProtectionFunc: func(_ context.Context, in *cloudwatchlogs.PutLogGroupDeletionProtectionInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogGroupDeletionProtectionOutput, error) {
+					if aws.ToBool(in.DeletionProtectionEnabled) {
+						t.Fatal("unexpected protection enabled")
+					}
+					calls = append(calls, "unprotect")
+					if tt.fields.unprotectErr != nil {
+						return nil, tt.fields.unprotectErr
+					}
+					return &cloudwatchlogs.PutLogGroupDeletionProtectionOutput{}, nil
+				},
+			})
+			e := &entry{
+				LogGroupName:       "test-log-group",
+				Region:             "us-east-1",
+				RetentionInDays:    365,
+				DeletionProtection: true,
+				name:               aws.String("test-log-group"),
+				client:             client,
+			}
+			tt.want.entry = e
+			man := &Manager{
+				clock:          nowFunc,
+				forceUnprotect: tt.fields.forceUnprotect,
+				dryRun:         tt.fields.dryRun,
+			}
+			got, err := man.apply(context.Background(), e, DesiredStateZero)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(ApplyEntry{}), cmpopts.IgnoreFields(ApplyEntry{}, "entry")); diff != "" {
+				t.Error(diff)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("calls mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManager_applyDuration(t *testing.T) {
+	client := newMockClient(&mockClient{
+		PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+			return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+		},
+	})
+	tests := []struct {
+		name   string
+		dryRun bool
+		want   time.Duration
+	}{
+		{
+			name:   "apply",
+			dryRun: false,
+			want:   time.Second,
+		},
+		{
+			name:   "dry run",
+			dryRun: true,
+			want:   time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := nowFunc()
+			man := &Manager{
+				clock: func() time.Time {
+					now = now.Add(time.Second)
+					return now
+				},
+				dryRun: tt.dryRun,
+			}
+			e := &entry{
+				LogGroupName:    "test-log-group",
+				Region:          "us-east-1",
+				RetentionInDays: 365,
+				name:            aws.String("test-log-group"),
+				client:          client,
+			}
+			got, err := man.apply(context.Background(), e, DesiredStateOneDay)
+			if err != nil {
+				t.Fatalf("Manager.apply() error = %v", err)
+			}
+			if got.Duration != tt.want {
+				t.Errorf("Manager.apply() Duration = %v, want %v", got.Duration, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_applyWithJournal(t *testing.T) {
+	client := newMockClient(&mockClient{
+		PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+			return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+		},
+	})
+	newEntry := func() *entry {
+		return &entry{
+			LogGroupName:    "test-log-group",
+			AccountID:       "123456789012",
+			Region:          "us-east-1",
+			RetentionInDays: 365,
+			name:            aws.String("test-log-group"),
+			client:          client,
+		}
+	}
+	t.Run("recorded", func(t *testing.T) {
+		var buf bytes.Buffer
+		man := &Manager{clock: nowFunc, journal: NewJournal(&buf)}
+		if _, err := man.apply(context.Background(), newEntry(), DesiredStateOneDay); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ParseJournal(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*JournalEntry{
+			{
+				Time:            mustTime("2025-04-01T00:00:00Z"),
+				LogGroupName:    "test-log-group",
+				AccountID:       "123456789012",
+				Region:          "us-east-1",
+				RetentionInDays: 365,
+				DesiredState:    DesiredStateOneDay,
+			},
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Error(diff)
+		}
+	})
+	t.Run("write fails", func(t *testing.T) {
+		man := &Manager{clock: nowFunc, journal: NewJournal(errWriter{})}
+		got, err := man.apply(context.Background(), newEntry(), DesiredStateOneDay)
+		if err == nil {
+			t.Fatal("Manager.apply() error = nil, want error")
+		}
+		if got.Success || got.RetentionAfter != 365 {
+			t.Errorf("Manager.apply() = %+v, want unchanged", got)
+		}
+	})
+}
+
+func TestManager_applyWithTags(t *testing.T) {
+	type fields struct {
+		dryRun bool
+	}
+	tests := []struct {
+		name     string
+		fields   fields
+		desired  DesiredState
+		tagErr   error
+		wantTags []map[string]string
+		wantErr  bool
+	}{
+		{
+			name:    "updated",
+			desired: DesiredStateOneDay,
+			wantTags: []map[string]string{
+				{
+					ManagedByTagKey:    "llcm",
+					AppliedAtTagKey:    "2025-04-01T00:00:00Z",
+					AppliedStateTagKey: "1day",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "deleted",
+			desired:  DesiredStateZero,
+			wantTags: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "dry run",
+			fields:   fields{dryRun: true},
+			desired:  DesiredStateOneDay,
+			wantTags: nil,
+			wantErr:  false,
+		},
+		{
+			name:    "tag error",
+			desired: DesiredStateOneDay,
+			tagErr:  errors.New("tag error"),
+			wantTags: []map[string]string{
+				{
+					ManagedByTagKey:    "llcm",
+					AppliedAtTagKey:    "2025-04-01T00:00:00Z",
+					AppliedStateTagKey: "1day",
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tags []map[string]string
+			client := newMockClient(&mockClient{
+				PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+					return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+				},
+				DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+					return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+				},
+				TagResourceFunc: func(_ context.Context, in *cloudwatchlogs.TagResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.TagResourceOutput, error) {
+					if aws.ToString(in.ResourceArn) != "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group" {
+						return nil, errors.New("unexpected arn")
+					}
+					tags = append(tags, in.Tags)
+					return &cloudwatchlogs.TagResourceOutput{}, tt.tagErr
+				},
+			})
+			e := &entry{
+				LogGroupName:    "test-log-group",
+				Region:          "us-east-1",
+				RetentionInDays: 365,
+				Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
+				name:            aws.String("test-log-group"),
+				client:          client,
+			}
+			man := &Manager{
+				clock:      nowFunc,
+				dryRun:     tt.fields.dryRun,
+				tagApplied: true,
+			}
+			got, err := man.apply(context.Background(), e, tt.desired)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Success {
+				t.Errorf("Manager.apply() success = %v, want true", got.Success)
+			}
+			if got.Error != "" {
+				t.Errorf("Manager.apply() result error = %q, want empty", got.Error)
+			}
+			if (got.TagError != "") != (tt.tagErr != nil) {
+				t.Errorf("Manager.apply() tag error = %q, want %v", got.TagError, tt.tagErr)
+			}
+			if diff := cmp.Diff(tt.wantTags, tags); diff != "" {
+				t.Errorf("tags mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
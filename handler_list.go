@@ -2,6 +2,7 @@ package llcm
 
 import (
 	"context"
+	"iter"
 	"sync"
 )
 
@@ -12,8 +13,9 @@ func (man *Manager) List(ctx context.Context) (*ListEntryData, error) {
 		mu    sync.Mutex
 	)
 	data := &ListEntryData{
-		header:  listEntryDataHeader,
-		entries: make([]*ListEntry, 0, entriesSize),
+		header:           man.header(listEntryDataHeader),
+		entries:          make([]*ListEntry, 0, entriesSize),
+		maxPieChartItems: man.maxPieChartItems,
 	}
 	fn := func(entry *entry) error {
 		e := &ListEntry{
@@ -25,9 +27,19 @@ func (man *Manager) List(ctx context.Context) (*ListEntryData, error) {
 		mu.Unlock()
 		return nil
 	}
-	if err := man.handle(ctx, fn); err != nil {
+	err := man.handle(ctx, fn)
+	if err != nil && !isErrors(err) {
 		return nil, err
 	}
 	data.TotalStoredBytes = total
-	return data, nil
+	return data, err
+}
+
+// All returns the iterator that yields the log group entries as the pages of the log groups arrive.
+// The entries are yielded in the order found, not sorted. The error is yielded at last with a nil entry,
+// which is the errors collected when continuing on error.
+func (man *Manager) All(ctx context.Context) iter.Seq2[*ListEntry, error] {
+	return stream(ctx, man, func(entry *entry) (*ListEntry, bool, error) {
+		return &ListEntry{entry: entry}, true, nil
+	})
 }
@@ -4,24 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/nekrassov01/filter"
 	"golang.org/x/sync/semaphore"
 )
 
 func TestManager_List(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		filterExpr         *filterExpr
-		sem                *semaphore.Weighted
+		client       *Client
+		accounts     []*Client
+		regions      []string
+		desiredState DesiredState
+		filterExpr   *filterExpr
+		pushdown     *pushdown
+		withTags     bool
+		withIdle     bool
+		withOrphan   bool
+		tagColumns   []string
+		sem          *semaphore.Weighted
 	}
 	type args struct {
 		ctx context.Context
@@ -139,12 +146,14 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 					},
@@ -153,6 +162,291 @@ func TestManager_List(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with tags",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(1024),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(2048),
+								},
+							},
+						}
+						return out, nil
+					},
+					ListTagsForResourceFunc: func(_ context.Context, in *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+						out := &cloudwatchlogs.ListTagsForResourceOutput{}
+						if aws.ToString(in.ResourceArn) == "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1" {
+							out.Tags = map[string]string{"env": "prod", "owner": "team-a"}
+						}
+						return out, nil
+					},
+				}),
+				regions:    []string{"us-east-1"},
+				filterExpr: func() *filterExpr { expr, _ := parseFilter(`tag.env == "prod"`); return expr }(),
+				withTags:   true,
+				tagColumns: []string{"owner"},
+				sem:        semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: append(listEntryDataHeader[:len(listEntryDataHeader):len(listEntryDataHeader)], "tag.owner"),
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
+							Tags:            map[string]string{"env": "prod", "owner": "team-a"},
+							name:            aws.String("test-log-group-1"),
+							tagColumns:      []string{"owner"},
+						},
+					},
+				},
+				TotalStoredBytes: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with tags error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName: aws.String("test-log-group"),
+									LogGroupArn:  aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+								},
+							},
+						}
+						return out, nil
+					},
+					ListTagsForResourceFunc: func(_ context.Context, _ *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+						return nil, errors.New("api error")
+					},
+				}),
+				regions:  []string{"us-east-1"},
+				withTags: true,
+				sem:      semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with idle",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(1024),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(2048),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-3"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-3"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(4096),
+								},
+							},
+						}
+						return out, nil
+					},
+					DescribeLogStreamsFunc: func(_ context.Context, in *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogStreamsOutput{}
+						switch aws.ToString(in.LogGroupName) {
+						case "test-log-group-1":
+							out.LogStreams = []types.LogStream{{LastEventTimestamp: aws.Int64(mustUnixMilli("2025-03-31T00:00:00Z"))}}
+						case "test-log-group-2":
+							out.LogStreams = []types.LogStream{{LastEventTimestamp: aws.Int64(mustUnixMilli("2025-01-31T00:00:00Z"))}}
+						}
+						return out, nil
+					},
+				}),
+				regions:    []string{"us-east-1"},
+				filterExpr: func() *filterExpr { expr, _ := parseFilter(`idle > 30d`); return expr }(),
+				withIdle:   true,
+				sem:        semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: append(listEntryDataHeader[:len(listEntryDataHeader):len(listEntryDataHeader)], "LastEventAt", "IdleDays"),
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-3",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     4096,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-3",
+							IdleDays:        90,
+							name:            aws.String("test-log-group-3"),
+							idleColumns:     true,
+						},
+					},
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
+							LastEventAt:     mustTime("2025-01-31T00:00:00Z"),
+							IdleDays:        60,
+							name:            aws.String("test-log-group-2"),
+							idleColumns:     true,
+						},
+					},
+				},
+				TotalStoredBytes: 6144,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with orphan",
+			fields: fields{
+				client: func() *Client {
+					c := newMockClient(&mockClient{
+						DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+							out := &cloudwatchlogs.DescribeLogGroupsOutput{
+								LogGroups: []types.LogGroup{
+									{
+										LogGroupName:    aws.String("/aws/lambda/deleted-function"),
+										LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/deleted-function"),
+										LogGroupClass:   types.LogGroupClassStandard,
+										CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+										RetentionInDays: aws.Int32(365),
+										StoredBytes:     aws.Int64(1024),
+									},
+									{
+										LogGroupName:    aws.String("/aws/lambda/existing-function"),
+										LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/existing-function"),
+										LogGroupClass:   types.LogGroupClassStandard,
+										CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+										RetentionInDays: aws.Int32(365),
+										StoredBytes:     aws.Int64(2048),
+									},
+								},
+							}
+							return out, nil
+						},
+					})
+					c.SetOwners((&fakeOwners{exists: map[string]bool{"existing-function": true}}).owners())
+					return c
+				}(),
+				regions:    []string{"us-east-1"},
+				filterExpr: func() *filterExpr { expr, _ := parseFilter(`orphaned == true`); return expr }(),
+				withOrphan: true,
+				sem:        semaphore.NewWeighted(1),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: append(listEntryDataHeader[:len(listEntryDataHeader):len(listEntryDataHeader)], "Orphaned"),
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "/aws/lambda/deleted-function",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/deleted-function",
+							Orphaned:        true,
+							name:            aws.String("/aws/lambda/deleted-function"),
+							orphanColumns:   true,
+						},
+					},
+				},
+				TotalStoredBytes: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with idle error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName: aws.String("test-log-group"),
+									LogGroupArn:  aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+								},
+							},
+						}
+						return out, nil
+					},
+					DescribeLogStreamsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+						return nil, errors.New("api error")
+					},
+				}),
+				regions:  []string{"us-east-1"},
+				withIdle: true,
+				sem:      semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "multiple entries",
 			fields: fields{
@@ -195,24 +489,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
@@ -288,48 +586,56 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-4",
+							AccountID:       "111111111111",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 731,
 							StoredBytes:     4096,
+							Arn:             "arn:aws:logs:us-east-2:111111111111:log-group:test-log-group-4",
 							name:            aws.String("test-log-group-4"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-3",
+							AccountID:       "000000000000",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 3,
 							StoredBytes:     0,
+							Arn:             "arn:aws:logs:us-east-2:000000000000:log-group:test-log-group-3",
 							name:            aws.String("test-log-group-3"),
 						},
 					},
@@ -338,6 +644,94 @@ func TestManager_List(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "multiple accounts",
+			fields: fields{
+				client: nil,
+				accounts: []*Client{
+					{
+						API: &mockClient{
+							DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+								out := &cloudwatchlogs.DescribeLogGroupsOutput{
+									LogGroups: []types.LogGroup{
+										{
+											LogGroupName:    aws.String("test-log-group-1"),
+											LogGroupArn:     aws.String("arn:aws:logs:us-east-1:111111111111:log-group:test-log-group-1"),
+											LogGroupClass:   types.LogGroupClassStandard,
+											CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+											RetentionInDays: aws.Int32(365),
+											StoredBytes:     aws.Int64(1024),
+										},
+									},
+								}
+								return out, nil
+							},
+						},
+						accountID: "111111111111",
+					},
+					{
+						API: &mockClient{
+							DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+								out := &cloudwatchlogs.DescribeLogGroupsOutput{
+									LogGroups: []types.LogGroup{
+										{
+											LogGroupName:    aws.String("test-log-group-1"),
+											LogGroupClass:   types.LogGroupClassStandard,
+											CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+											RetentionInDays: aws.Int32(7),
+											StoredBytes:     aws.Int64(2048),
+										},
+									},
+								}
+								return out, nil
+							},
+						},
+						accountID: "222222222222",
+					},
+				},
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: listEntryDataHeader,
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "222222222222",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 7,
+							StoredBytes:     2048,
+							name:            aws.String("test-log-group-1"),
+						},
+					},
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "111111111111",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:111111111111:log-group:test-log-group-1",
+							name:            aws.String("test-log-group-1"),
+						},
+					},
+				},
+				TotalStoredBytes: 3072,
+			},
+			wantErr: false,
+		},
 		{
 			name: "multiple regions",
 			fields: fields{
@@ -380,48 +774,56 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
@@ -464,12 +866,14 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 9999,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 					},
@@ -520,24 +924,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
@@ -546,6 +954,235 @@ func TestManager_List(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "with filter of simulated result",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(1024),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassInfrequentAccess,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(7),
+									StoredBytes:     aws.Int64(2048),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateOneWeek,
+				filterExpr:   func() *filterExpr { expr, _ := filter.Parse(`action == "update"`); return expr }(),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with filter region and created at",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2021-06-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(1024),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(2048),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1", "eu-west-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   func() *filterExpr { expr, _ := parseFilter(`region =~ "^eu" && created < "2022-01-01"`); return expr }(),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: listEntryDataHeader,
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							Region:          "eu-west-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2021-06-01T00:00:00Z"),
+							ElapsedDays:     1400,
+							RetentionInDays: 365,
+							StoredBytes:     1024,
+							name:            aws.String("test-log-group-1"),
+						},
+					},
+				},
+				TotalStoredBytes: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with filter kms and metric filters",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:         aws.String("test-log-group-1"),
+									LogGroupArn:          aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:        types.LogGroupClassStandard,
+									CreationTime:         aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:      aws.Int32(365),
+									StoredBytes:          aws.Int64(1024),
+									DataProtectionStatus: types.DataProtectionStatusActivated,
+									MetricFilterCount:    aws.Int32(2),
+									InheritedProperties:  []types.InheritedProperty{types.InheritedPropertyAccountDataProtection},
+								},
+								{
+									LogGroupName:      aws.String("test-log-group-2"),
+									Arn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2:*"),
+									LogGroupClass:     types.LogGroupClassStandard,
+									CreationTime:      aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:   aws.Int32(365),
+									StoredBytes:       aws.Int64(2048),
+									KmsKeyId:          aws.String("arn:aws:kms:us-east-1:123456789012:key/test-key"),
+									MetricFilterCount: aws.Int32(1),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-3"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-3"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(4096),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   func() *filterExpr { expr, _ := filter.Parse(`kms == "" && metricFilters > 0`); return expr }(),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: listEntryDataHeader,
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:         "test-log-group-1",
+							AccountID:            "123456789012",
+							Region:               "us-east-1",
+							Class:                types.LogGroupClassStandard,
+							CreatedAt:            mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:          90,
+							RetentionInDays:      365,
+							StoredBytes:          1024,
+							Arn:                  "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
+							DataProtectionStatus: types.DataProtectionStatusActivated,
+							MetricFilterCount:    2,
+							InheritedProperties:  []types.InheritedProperty{types.InheritedPropertyAccountDataProtection},
+							name:                 aws.String("test-log-group-1"),
+						},
+					},
+				},
+				TotalStoredBytes: 1024,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with filter pushdown",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						if aws.ToString(params.LogGroupNamePrefix) != "/aws/lambda/" || params.LogGroupClass != types.LogGroupClassStandard {
+							return nil, fmt.Errorf("unexpected input: %v, %v", aws.ToString(params.LogGroupNamePrefix), params.LogGroupClass)
+						}
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("/aws/lambda/func-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/func-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(1024),
+								},
+								{
+									LogGroupName:    aws.String("/aws/lambda/func-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/func-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(365),
+									StoredBytes:     aws.Int64(0),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   func() *filterExpr { expr, _ := filter.Parse(`bytes > 0`); return expr }(),
+				pushdown:     newPushdown(`name =~ "^/aws/lambda/" && class == "STANDARD" && bytes > 0`),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &ListEntryData{
+				header: listEntryDataHeader,
+				entries: []*ListEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "/aws/lambda/func-1",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: 365,
+							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/func-1",
+							name:            aws.String("/aws/lambda/func-1"),
+						},
+					},
+				},
+				TotalStoredBytes: 1024,
+			},
+			wantErr: false,
+		},
 		{
 			name: "with filter class",
 			fields: fields{
@@ -588,24 +1225,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 365,
 							StoredBytes:     1024,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 					},
@@ -649,6 +1290,7 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:       "test-log-group",
+							AccountID:          "123456789012",
 							Region:             "us-east-1",
 							Class:              types.LogGroupClassStandard,
 							CreatedAt:          mustTime("2025-01-01T00:00:00Z"),
@@ -656,6 +1298,7 @@ func TestManager_List(t *testing.T) {
 							ElapsedDays:        90,
 							RetentionInDays:    365,
 							StoredBytes:        1024,
+							Arn:                "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:               aws.String("test-log-group"),
 						},
 					},
@@ -706,24 +1349,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
@@ -774,24 +1421,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
@@ -845,24 +1496,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
@@ -913,24 +1568,28 @@ func TestManager_List(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassInfrequentAccess,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: 7,
 							StoredBytes:     2048,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 					},
@@ -1060,12 +1719,18 @@ func TestManager_List(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				filterExpr:         tt.fields.filterExpr,
-				sem:                tt.fields.sem,
+				clock:        nowFunc,
+				client:       tt.fields.client,
+				accounts:     tt.fields.accounts,
+				regions:      tt.fields.regions,
+				desiredState: tt.fields.desiredState,
+				filterExpr:   tt.fields.filterExpr,
+				pushdown:     tt.fields.pushdown,
+				withTags:     tt.fields.withTags,
+				withIdle:     tt.fields.withIdle,
+				withOrphan:   tt.fields.withOrphan,
+				tagColumns:   tt.fields.tagColumns,
+				sem:          tt.fields.sem,
 			}
 			got, err := man.List(tt.args.ctx)
 			if (err != nil) != tt.wantErr {
@@ -1075,10 +1740,108 @@ func TestManager_List(t *testing.T) {
 			if got != nil && got.entries != nil {
 				SortEntries(got)
 			}
-			opt := cmp.AllowUnexported(ListEntryData{}, ListEntry{}, entry{})
+			opt := cmp.Options{
+				cmp.AllowUnexported(ListEntryData{}, ListEntry{}, entry{}),
+				cmpopts.IgnoreFields(entry{}, "client"),
+			}
 			if diff := cmp.Diff(tt.want, got, opt); diff != "" {
 				t.Errorf("Manager.List() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestManager_All(t *testing.T) {
+	pages := func(err error) *Client {
+		return newMockClient(&mockClient{
+			DescribeLogGroupsFunc: func(_ context.Context, in *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+				if err != nil {
+					return nil, err
+				}
+				if in.NextToken == nil {
+					out := &cloudwatchlogs.DescribeLogGroupsOutput{
+						LogGroups: []types.LogGroup{
+							{LogGroupName: aws.String("group0"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(1024)},
+							{LogGroupName: aws.String("group1"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(2048)},
+						},
+						NextToken: aws.String("token"),
+					}
+					return out, nil
+				}
+				out := &cloudwatchlogs.DescribeLogGroupsOutput{
+					LogGroups: []types.LogGroup{
+						{LogGroupName: aws.String("group2"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(4096)},
+					},
+				}
+				return out, nil
+			},
+		})
+	}
+	tests := []struct {
+		name    string
+		client  *Client
+		stop    int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "all pages",
+			client:  pages(nil),
+			stop:    0,
+			want:    []string{"group0", "group1", "group2"},
+			wantErr: false,
+		},
+		{
+			name:    "stop iteration",
+			client:  pages(nil),
+			stop:    1,
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "api error",
+			client:  pages(errors.New("api error")),
+			stop:    0,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:   nowFunc,
+				client:  tt.client,
+				regions: []string{"us-east-1"},
+				sem:     semaphore.NewWeighted(10),
+			}
+			var (
+				got []string
+				err error
+			)
+			for e, e2 := range man.All(context.Background()) {
+				if e2 != nil {
+					err = e2
+					break
+				}
+				got = append(got, e.LogGroupName)
+				if len(got) == tt.stop {
+					break
+				}
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.All() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.stop > 0 {
+				if len(got) != tt.stop {
+					t.Errorf("Manager.All() yielded %d entries after stop, want %d", len(got), tt.stop)
+				}
+				return
+			}
+			slices.Sort(got)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Manager.All() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// applyPlan applies the desired state only to the log groups in the plan.
+// Before changing anything, the current state of each log group is compared with the prior state in the plan.
+// If any log group has drifted, it fails unless drift is allowed, in which case the drifted ones are skipped.
+// The limits and the confirmation are checked against the remaining log groups.
+// The results are returned per plan entry in the order of the plan.
+func (man *Manager) applyPlan(ctx context.Context) (*ApplyEntryData, error) {
+	var (
+		entries = man.plan.Entries
+		current = make([]*entry, len(entries))
+		failed  = make([]error, len(entries))
+		errs    Errors
+	)
+	err := man.each(ctx, len(entries), func(ctx context.Context, i int) error {
+		e, err := man.describeLogGroup(ctx, entries[i].AccountID, entries[i].Region, entries[i].LogGroupName)
+		if err != nil {
+			failed[i] = err
+			return newEntryError(entries[i].AccountID, entries[i].Region, entries[i].toEntry(nil), err)
+		}
+		current[i] = e
+		return nil
+	})
+	if err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+	reasons := make([]string, len(entries))
+	drifted := make([]string, 0, len(entries))
+	for i, e := range entries {
+		if failed[i] != nil {
+			continue
+		}
+		if reason := e.drift(current[i]); reason != "" {
+			reasons[i] = reason
+			drifted = append(drifted, fmt.Sprintf("%s (%s)", e.LogGroupName, reason))
+		}
+	}
+	if len(drifted) > 0 && !man.allowDrift {
+		return nil, fmt.Errorf("plan is stale: %d of %d log groups drifted: %s", len(drifted), len(entries), strings.Join(drifted, ", "))
+	}
+	targets := make([]*applyTarget, 0, len(entries))
+	for i, e := range entries {
+		if failed[i] == nil && reasons[i] == "" {
+			targets = append(targets, &applyTarget{entry: current[i], desired: e.DesiredState})
+		}
+	}
+	if err := man.guard(ctx, newApplySummary(targets, man.mode, man.forceUnprotect)); err != nil {
+		return nil, err
+	}
+	results := make([]*ApplyEntry, len(entries))
+	err = man.each(ctx, len(entries), func(ctx context.Context, i int) error {
+		switch {
+		case failed[i] != nil:
+			e := newApplyEntry(entries[i].toEntry(nil), entries[i].DesiredState)
+			e.Error = failed[i].Error()
+			results[i] = e
+			return nil
+		case reasons[i] != "":
+			e := newApplyEntry(entries[i].toEntry(current[i]), entries[i].DesiredState)
+			e.Action = ActionSkip
+			e.Error = reasons[i]
+			results[i] = e
+			return nil
+		}
+		e, err := man.apply(ctx, current[i], entries[i].DesiredState)
+		results[i] = e
+		if err != nil {
+			return newEntryError(entries[i].AccountID, entries[i].Region, current[i], err)
+		}
+		return nil
+	})
+	data := &ApplyEntryData{
+		header:           applyEntryDataHeader,
+		entries:          make([]*ApplyEntry, 0, len(entries)),
+		maxPieChartItems: man.maxPieChartItems,
+	}
+	for _, e := range results {
+		if e != nil {
+			data.add(e)
+		}
+	}
+	var applyErrs Errors
+	if err != nil && !errors.As(err, &applyErrs) {
+		return data, err
+	}
+	errs = append(errs, applyErrs...)
+	if len(errs) > 0 {
+		errs.sort()
+		return data, errs
+	}
+	return data, nil
+}
+
+// describeLogGroup returns the current entry of the log group with the specified name.
+// It returns nil if the log group no longer exists in the specified account and region.
+func (man *Manager) describeLogGroup(ctx context.Context, accountID, region, name string) (*entry, error) {
+	client, err := man.clientFor(accountID)
+	if err != nil {
+		return nil, err
+	}
+	key := limitKey{accountID: client.accountID, region: region, api: apiDescribeLogGroups}
+	in := &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(name),
+	}
+	for {
+		var out *cloudwatchlogs.DescribeLogGroupsOutput
+		err := man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) (err error) {
+			out, err = client.DescribeLogGroups(ctx, in, opt)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, logGroup := range out.LogGroups {
+			if aws.ToString(logGroup.LogGroupName) != name {
+				continue
+			}
+			current := newEntry(logGroup, region, client, man.now())
+			if accountID != "" && current.AccountID != accountID {
+				continue
+			}
+			return current, nil
+		}
+		if out.NextToken == nil {
+			return nil, nil
+		}
+		in.NextToken = out.NextToken
+	}
+}
+
+// each runs the function concurrently for each index up to n within the limit of the semaphore.
+// By default, it stops at the first error and returns the underlying error.
+// If continuing on error, it runs for all indexes and returns the collected errors as Errors.
+func (man *Manager) each(ctx context.Context, n int, fn func(context.Context, int) error) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs Errors
+	)
+	ctx, cancel := context.WithCancel(ctx)
+	errorChan := make(chan error, 1)
+	defer cancel()
+	for i := range n {
+		if err := man.sem.Acquire(ctx, 1); err != nil {
+			break
+		}
+		wg.Go(func() {
+			defer man.sem.Release(1)
+			err := fn(ctx, i)
+			if err == nil {
+				return
+			}
+			var ee *EntryError
+			if !errors.As(err, &ee) {
+				ee = newEntryError("", "", nil, err)
+			}
+			if man.continueOnError {
+				mu.Lock()
+				errs = append(errs, ee)
+				mu.Unlock()
+				return
+			}
+			select {
+			case errorChan <- ee.err:
+				cancel()
+			default:
+			}
+		})
+	}
+	wg.Wait()
+	close(errorChan)
+	if err, ok := <-errorChan; ok {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/semaphore"
+)
+
+// describeLogGroupsFunc is helper function to return log groups matching the prefix.
+func describeLogGroupsFunc(logGroups ...types.LogGroup) func(context.Context, *cloudwatchlogs.DescribeLogGroupsInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return func(_ context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+		out := &cloudwatchlogs.DescribeLogGroupsOutput{}
+		for _, logGroup := range logGroups {
+			if strings.HasPrefix(aws.ToString(logGroup.LogGroupName), aws.ToString(params.LogGroupNamePrefix)) {
+				out.LogGroups = append(out.LogGroups, logGroup)
+			}
+		}
+		return out, nil
+	}
+}
+
+func TestManager_applyPlan(t *testing.T) {
+	logGroups := []types.LogGroup{
+		{
+			LogGroupName:              aws.String("test-log-group-1"),
+			LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+			RetentionInDays:           aws.Int32(365),
+			StoredBytes:               aws.Int64(1024),
+			DeletionProtectionEnabled: aws.Bool(false),
+		},
+		{
+			LogGroupName:              aws.String("test-log-group-10"),
+			LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-10"),
+			RetentionInDays:           aws.Int32(7),
+			StoredBytes:               aws.Int64(2048),
+			DeletionProtectionEnabled: aws.Bool(false),
+		},
+	}
+	plan := &Plan{
+		Entries: []*PlanEntry{
+			{
+				LogGroupName:    "test-log-group-1",
+				AccountID:       "123456789012",
+				Region:          "us-east-1",
+				RetentionInDays: 365,
+				DesiredState:    DesiredStateOneMonth,
+			},
+			{
+				LogGroupName:    "test-log-group-10",
+				AccountID:       "123456789012",
+				Region:          "us-east-1",
+				RetentionInDays: 7,
+				DesiredState:    DesiredStateZero,
+			},
+		},
+	}
+	driftedPlan := &Plan{
+		Entries: []*PlanEntry{
+			{
+				LogGroupName:    "test-log-group-1",
+				AccountID:       "123456789012",
+				Region:          "us-east-1",
+				RetentionInDays: 30,
+				DesiredState:    DesiredStateOneWeek,
+			},
+			{
+				LogGroupName:    "test-log-group-10",
+				AccountID:       "123456789012",
+				Region:          "us-east-1",
+				RetentionInDays: 7,
+				DesiredState:    DesiredStateZero,
+			},
+		},
+	}
+	put := func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+		return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+	}
+	del := func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+		return &cloudwatchlogs.DeleteLogGroupOutput{}, nil
+	}
+	type fields struct {
+		client          *Client
+		accounts        []*Client
+		plan            *Plan
+		allowDrift      bool
+		continueOnError bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "no drift",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc:  describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: put,
+					DeleteLogGroupFunc:     del,
+				}),
+				plan:       plan,
+				allowDrift: false,
+			},
+			want:    []string{"test-log-group-1 update true ", "test-log-group-10 delete true "},
+			wantErr: false,
+		},
+		{
+			name: "drift refused",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+						return nil, errors.New("must not be called")
+					},
+					DeleteLogGroupFunc: func(_ context.Context, _ *cloudwatchlogs.DeleteLogGroupInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteLogGroupOutput, error) {
+						return nil, errors.New("must not be called")
+					},
+				}),
+				plan:       driftedPlan,
+				allowDrift: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "drift allowed",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc:  describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: put,
+					DeleteLogGroupFunc:     del,
+				}),
+				plan:       driftedPlan,
+				allowDrift: true,
+			},
+			want:    []string{"test-log-group-1 skip false retention changed: 30 -> 365", "test-log-group-10 delete true "},
+			wantErr: false,
+		},
+		{
+			name: "not exist",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc:  describeLogGroupsFunc(logGroups[1]),
+					PutRetentionPolicyFunc: put,
+					DeleteLogGroupFunc:     del,
+				}),
+				plan:       plan,
+				allowDrift: true,
+			},
+			want:    []string{"test-log-group-1 skip false log group no longer exists", "test-log-group-10 delete true "},
+			wantErr: false,
+		},
+		{
+			name: "different account",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(types.LogGroup{
+						LogGroupName:    aws.String("test-log-group-1"),
+						LogGroupArn:     aws.String("arn:aws:logs:us-east-1:210987654321:log-group:test-log-group-1"),
+						RetentionInDays: aws.Int32(365),
+					}),
+				}),
+				plan: &Plan{
+					Entries: plan.Entries[:1],
+				},
+				allowDrift: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "describe log groups returns error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						return nil, errors.New("error")
+					},
+				}),
+				plan:       plan,
+				allowDrift: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "apply returns error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+						return nil, errors.New("error")
+					},
+				}),
+				plan: &Plan{
+					Entries: plan.Entries[:1],
+				},
+				allowDrift: false,
+			},
+			want:    []string{"test-log-group-1 update false error"},
+			wantErr: true,
+		},
+		{
+			name: "continue on error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+						return nil, errors.New("error")
+					},
+					DeleteLogGroupFunc: del,
+				}),
+				plan:            plan,
+				allowDrift:      false,
+				continueOnError: true,
+			},
+			want:    []string{"test-log-group-1 update false error", "test-log-group-10 delete true "},
+			wantErr: true,
+		},
+		{
+			name: "continue on describe error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						if aws.ToString(params.LogGroupNamePrefix) == "test-log-group-1" {
+							return nil, errors.New("error")
+						}
+						return describeLogGroupsFunc(logGroups...)(context.Background(), params)
+					},
+					DeleteLogGroupFunc: del,
+				}),
+				plan:            plan,
+				allowDrift:      false,
+				continueOnError: true,
+			},
+			want:    []string{"test-log-group-1 none false error", "test-log-group-10 delete true "},
+			wantErr: true,
+		},
+		{
+			name: "unknown account",
+			fields: fields{
+				client: newMockClient(&mockClient{}),
+				accounts: []*Client{
+					{API: &mockClient{}, accountID: "210987654321"},
+				},
+				plan:       plan,
+				allowDrift: false,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:           nowFunc,
+				client:          tt.fields.client,
+				accounts:        tt.fields.accounts,
+				plan:            tt.fields.plan,
+				allowDrift:      tt.fields.allowDrift,
+				continueOnError: tt.fields.continueOnError,
+				assumeYes:       true,
+				sem:             semaphore.NewWeighted(10),
+			}
+			data, err := man.Apply(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			if data != nil {
+				for _, e := range data.entries {
+					got = append(got, fmt.Sprintf("%s %s %t %s", e.LogGroupName, e.Action, e.Success, e.Error))
+				}
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package llcm
 
 import (
 	"context"
+	"errors"
+	"iter"
 	"sync"
 )
 
 // Preview returns the log group entries with the desired state and its simulated results.
+// If the result filter is set, only the entries whose simulated results match it are returned.
 func (man *Manager) Preview(ctx context.Context) (*PreviewEntryData, error) {
 	var (
 		totalStoredBytes    int64
@@ -14,14 +17,18 @@ func (man *Manager) Preview(ctx context.Context) (*PreviewEntryData, error) {
 		mu                  sync.Mutex
 	)
 	data := &PreviewEntryData{
-		header:  previewEntryDataHeader,
-		entries: make([]*PreviewEntry, 0, entriesSize),
+		header:         man.header(previewEntryDataHeader),
+		entries:        make([]*PreviewEntry, 0, entriesSize),
+		mode:           man.mode,
+		forceUnprotect: man.forceUnprotect,
+		previewedAt:    man.now(),
 	}
+	issues := &tagIssueSet{}
 	fn := func(entry *entry) error {
-		e := &PreviewEntry{
-			entry: entry,
+		e, ok, err := man.preview(entry, issues)
+		if err != nil || !ok {
+			return err
 		}
-		e.simulate(man.desiredState)
 		mu.Lock()
 		data.entries = append(data.entries, e)
 		totalStoredBytes += e.StoredBytes
@@ -30,11 +37,67 @@ func (man *Manager) Preview(ctx context.Context) (*PreviewEntryData, error) {
 		mu.Unlock()
 		return nil
 	}
-	if err := man.handle(ctx, fn); err != nil {
+	err := man.handle(ctx, fn)
+	if err != nil && !isErrors(err) {
 		return nil, err
 	}
 	data.TotalStoredBytes = totalStoredBytes
 	data.TotalReducibleBytes = totalReducibleBytes
 	data.TotalRemainingBytes = totalRemainingBytes
-	return data, nil
+	data.tagIssues = issues.sorted()
+	return data, err
+}
+
+// PreviewAll returns the iterator that yields the log group entries with the desired state and its simulated results
+// as the pages of the log groups arrive. The entries are yielded in the order found, not sorted.
+// The error is yielded at last with a nil entry, which is the errors collected when continuing on error
+// joined with the TagIssues if the tags of the log groups yielded or skipped do not declare the desired state.
+// The fatal error is yielded as it is without the TagIssues.
+func (man *Manager) PreviewAll(ctx context.Context) iter.Seq2[*PreviewEntry, error] {
+	return func(yield func(*PreviewEntry, error) bool) {
+		issues := &tagIssueSet{}
+		var err error
+		for e, e2 := range stream(ctx, man, func(entry *entry) (*PreviewEntry, bool, error) {
+			return man.preview(entry, issues)
+		}) {
+			if e2 != nil {
+				err = e2
+				break
+			}
+			if !yield(e, nil) {
+				return
+			}
+		}
+		if t := issues.sorted(); len(t) > 0 && (err == nil || isErrors(err)) {
+			err = errors.Join(err, t)
+		}
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// preview returns the entry with the desired state and its simulated results.
+// It reports false if no desired state is resolved or the simulated results do not match the result filter.
+// The issue of the tag is added to the issues only if the entry matches the result filter,
+// which is simulated with no desired state if the tag does not declare it without the default.
+func (man *Manager) preview(entry *entry, issues *tagIssueSet) (*PreviewEntry, bool, error) {
+	desired, rule, ok, err := man.resolve(entry)
+	if err != nil {
+		return nil, false, err
+	}
+	issue := man.tagIssue(entry)
+	if !ok && issue == nil {
+		return nil, false, nil
+	}
+	e := &PreviewEntry{
+		entry: entry,
+		Rule:  rule,
+	}
+	e.simulate(desired, man.mode, man.forceUnprotect)
+	if match, err := man.matchResult(e); err != nil || !match {
+		return nil, false, err
+	}
+	issues.add(issue)
+	return e, ok, nil
 }
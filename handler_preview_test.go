@@ -8,17 +8,21 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"golang.org/x/sync/semaphore"
 )
 
 func TestManager_Preview(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		filterExpr         *filterExpr
-		sem                *semaphore.Weighted
+		client         *Client
+		regions        []string
+		desiredState   DesiredState
+		policy         *Policy
+		mode           Mode
+		forceUnprotect bool
+		filterExpr     *filterExpr
+		resultExpr     *filterExpr
+		sem            *semaphore.Weighted
 	}
 	type args struct {
 		ctx context.Context
@@ -63,20 +67,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 600,
 				TotalRemainingBytes: 300,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 60,
 						ReducibleBytes:  600,
 						RemainingBytes:  300,
@@ -126,20 +134,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 1200,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-2",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateTwoMonths),
 							StoredBytes:     1200,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2",
 							name:            aws.String("test-log-group-2"),
 						},
 						BytesPerDay:     20,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 30,
 						ReducibleBytes:  600,
 						RemainingBytes:  600,
@@ -147,16 +159,88 @@ func TestManager_Preview(t *testing.T) {
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
 							name:            aws.String("test-log-group-1"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
+						ReductionInDays: 60,
+						ReducibleBytes:  600,
+						RemainingBytes:  300,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "policy",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateThreeMonths)),
+									StoredBytes:     aws.Int64(900),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateTwoMonths)),
+									StoredBytes:     aws.Int64(1200),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateNone,
+				policy:       mustPolicy("rules:\n  - name: group1\n    filter: name == \"test-log-group-1\"\n    desired: 1month\n"),
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    900,
+				TotalReducibleBytes: 600,
+				TotalRemainingBytes: 300,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: int64(DesiredStateThreeMonths),
+							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
+							name:            aws.String("test-log-group-1"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateOneMonth,
+						Rule:            "group1",
+						Action:          ActionUpdate,
 						ReductionInDays: 60,
 						ReducibleBytes:  600,
 						RemainingBytes:  300,
@@ -198,20 +282,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     0,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     0,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  0,
@@ -253,20 +341,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 600,
 				TotalRemainingBytes: 300,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 60,
 						ReducibleBytes:  600,
 						RemainingBytes:  300,
@@ -308,20 +400,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-04-01T00:00:00Z"),
 							ElapsedDays:     0,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     900,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -363,20 +459,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 900,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 90,
 						ReducibleBytes:  900,
 						RemainingBytes:  0,
@@ -418,20 +518,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-04-01T00:00:00Z"),
 							ElapsedDays:     0,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     900,
 						DesiredState:    DesiredStateOneMonth,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -473,20 +577,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateInfinite,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -528,20 +636,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-04-01T00:00:00Z"),
 							ElapsedDays:     0,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     900,
 						DesiredState:    DesiredStateInfinite,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -583,20 +695,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateProtected,
+						Action:          ActionUpdate,
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -638,20 +754,349 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 0,
 				TotalRemainingBytes: 900,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateUnprotected,
+						Action:          ActionNoop,
+						ReductionInDays: 0,
+						ReducibleBytes:  0,
+						RemainingBytes:  900,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "desired zero retention with deletion protection",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:              aws.String("test-log-group"),
+									LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(int32(DesiredStateThreeMonths)),
+									StoredBytes:               aws.Int64(900),
+									DeletionProtectionEnabled: aws.Bool(true),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateZero,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    900,
+				TotalReducibleBytes: 0,
+				TotalRemainingBytes: 900,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:       "test-log-group",
+							AccountID:          "123456789012",
+							Region:             "us-east-1",
+							Class:              types.LogGroupClassStandard,
+							CreatedAt:          mustTime("2025-01-01T00:00:00Z"),
+							DeletionProtection: true,
+							ElapsedDays:        90,
+							RetentionInDays:    int64(DesiredStateThreeMonths),
+							StoredBytes:        900,
+							Arn:                "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
+							name:               aws.String("test-log-group"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateZero,
+						Action:          ActionSkip,
+						Reason:          "log group is protected from deletion",
+						ReductionInDays: 0,
+						ReducibleBytes:  0,
+						RemainingBytes:  900,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "desired zero retention with deletion protection forced to unprotect",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:              aws.String("test-log-group"),
+									LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+									LogGroupClass:             types.LogGroupClassStandard,
+									CreationTime:              aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays:           aws.Int32(int32(DesiredStateThreeMonths)),
+									StoredBytes:               aws.Int64(900),
+									DeletionProtectionEnabled: aws.Bool(true),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:        []string{"us-east-1"},
+				desiredState:   DesiredStateZero,
+				forceUnprotect: true,
+				filterExpr:     nil,
+				sem:            semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    900,
+				TotalReducibleBytes: 900,
+				TotalRemainingBytes: 0,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				forceUnprotect:      true,
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:       "test-log-group",
+							AccountID:          "123456789012",
+							Region:             "us-east-1",
+							Class:              types.LogGroupClassStandard,
+							CreatedAt:          mustTime("2025-01-01T00:00:00Z"),
+							DeletionProtection: true,
+							ElapsedDays:        90,
+							RetentionInDays:    int64(DesiredStateThreeMonths),
+							StoredBytes:        900,
+							Arn:                "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
+							name:               aws.String("test-log-group"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
+						ReductionInDays: 90,
+						ReducibleBytes:  900,
+						RemainingBytes:  0,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "with result filter reducible bytes",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2024-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateOneYear)),
+									StoredBytes:     aws.Int64(3650),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2024-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateOneMonth)),
+									StoredBytes:     aws.Int64(300),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateThreeMonths,
+				resultExpr: func() *filterExpr {
+					expr, _ := parseFilter(`reducibleBytes > 0 && desiredState == "3months"`)
+					return expr
+				}(),
+				sem: semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    3650,
+				TotalReducibleBytes: 2750,
+				TotalRemainingBytes: 900,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2024-01-01T00:00:00Z"),
+							ElapsedDays:     456,
+							RetentionInDays: int64(DesiredStateOneYear),
+							StoredBytes:     3650,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
+							name:            aws.String("test-log-group-1"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateThreeMonths,
+						Action:          ActionUpdate,
+						ReductionInDays: 275,
+						ReducibleBytes:  2750,
+						RemainingBytes:  900,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "with result filter",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group-1"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2024-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateOneYear)),
+									StoredBytes:     aws.Int64(3650),
+								},
+								{
+									LogGroupName:    aws.String("test-log-group-2"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2024-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateOneMonth)),
+									StoredBytes:     aws.Int64(300),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateThreeMonths,
+				filterExpr:   func() *filterExpr { expr, _ := parseFilter(`class == "STANDARD"`); return expr }(),
+				resultExpr:   func() *filterExpr { expr, _ := parseFilter(`reducibleBytes > 1024`); return expr }(),
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    3650,
+				TotalReducibleBytes: 2750,
+				TotalRemainingBytes: 900,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group-1",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2024-01-01T00:00:00Z"),
+							ElapsedDays:     456,
+							RetentionInDays: int64(DesiredStateOneYear),
+							StoredBytes:     3650,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1",
+							name:            aws.String("test-log-group-1"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateThreeMonths,
+						Action:          ActionUpdate,
+						ReductionInDays: 275,
+						ReducibleBytes:  2750,
+						RemainingBytes:  900,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "shorten only mode",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						out := &cloudwatchlogs.DescribeLogGroupsOutput{
+							LogGroups: []types.LogGroup{
+								{
+									LogGroupName:    aws.String("test-log-group"),
+									LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group"),
+									LogGroupClass:   types.LogGroupClassStandard,
+									CreationTime:    aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")),
+									RetentionInDays: aws.Int32(int32(DesiredStateThreeMonths)),
+									StoredBytes:     aws.Int64(900),
+								},
+							},
+						}
+						return out, nil
+					},
+				}),
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateOneYear,
+				mode:         ModeShortenOnly,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(10),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			want: &PreviewEntryData{
+				TotalStoredBytes:    900,
+				TotalReducibleBytes: 0,
+				TotalRemainingBytes: 900,
+				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
+				mode:                ModeShortenOnly,
+				entries: []*PreviewEntry{
+					{
+						entry: &entry{
+							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
+							Region:          "us-east-1",
+							Class:           types.LogGroupClassStandard,
+							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+							ElapsedDays:     90,
+							RetentionInDays: int64(DesiredStateThreeMonths),
+							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
+							name:            aws.String("test-log-group"),
+						},
+						BytesPerDay:     10,
+						DesiredState:    DesiredStateOneYear,
+						Action:          ActionSkip,
+						Reason:          "lengthening retention is not allowed in shorten-only mode",
 						ReductionInDays: 0,
 						ReducibleBytes:  0,
 						RemainingBytes:  900,
@@ -693,20 +1138,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 900,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     10,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 90,
 						ReducibleBytes:  900,
 						RemainingBytes:  0,
@@ -748,20 +1197,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 900,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-04-01T00:00:00Z"),
 							ElapsedDays:     0,
 							RetentionInDays: int64(DesiredStateThreeMonths),
 							StoredBytes:     900,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     900,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 90,
 						ReducibleBytes:  900,
 						RemainingBytes:  0,
@@ -803,20 +1256,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 100,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateOneDay),
 							StoredBytes:     100,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     100,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 1,
 						ReducibleBytes:  100,
 						RemainingBytes:  0,
@@ -857,20 +1314,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 100,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-04-01T00:00:00Z"),
 							ElapsedDays:     0,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     100,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     100,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 1,
 						ReducibleBytes:  100,
 						RemainingBytes:  0,
@@ -911,20 +1372,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 10,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     10,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     1,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 90,
 						ReducibleBytes:  10,
 						RemainingBytes:  0,
@@ -965,20 +1430,24 @@ func TestManager_Preview(t *testing.T) {
 				TotalReducibleBytes: 90,
 				TotalRemainingBytes: 0,
 				header:              previewEntryDataHeader,
+				previewedAt:         mustTime("2025-04-01T00:00:00Z"),
 				entries: []*PreviewEntry{
 					{
 						entry: &entry{
 							LogGroupName:    "test-log-group",
+							AccountID:       "123456789012",
 							Region:          "us-east-1",
 							Class:           types.LogGroupClassStandard,
 							CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
 							ElapsedDays:     90,
 							RetentionInDays: int64(DesiredStateInfinite),
 							StoredBytes:     90,
+							Arn:             "arn:aws:logs:us-east-1:123456789012:log-group:test-log-group",
 							name:            aws.String("test-log-group"),
 						},
 						BytesPerDay:     1,
 						DesiredState:    DesiredStateZero,
+						Action:          ActionDelete,
 						ReductionInDays: 90,
 						ReducibleBytes:  90,
 						RemainingBytes:  0,
@@ -1015,12 +1484,16 @@ func TestManager_Preview(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				filterExpr:         tt.fields.filterExpr,
-				sem:                tt.fields.sem,
+				clock:          nowFunc,
+				client:         tt.fields.client,
+				regions:        tt.fields.regions,
+				desiredState:   tt.fields.desiredState,
+				policy:         tt.fields.policy,
+				mode:           tt.fields.mode,
+				forceUnprotect: tt.fields.forceUnprotect,
+				filterExpr:     tt.fields.filterExpr,
+				resultExpr:     tt.fields.resultExpr,
+				sem:            tt.fields.sem,
 			}
 			got, err := man.Preview(tt.args.ctx)
 			if (err != nil) != tt.wantErr {
@@ -1030,10 +1503,80 @@ func TestManager_Preview(t *testing.T) {
 			if got != nil && got.entries != nil {
 				SortEntries(got)
 			}
-			opt := cmp.AllowUnexported(PreviewEntryData{}, PreviewEntry{}, entry{})
+			opt := cmp.Options{
+				cmp.AllowUnexported(PreviewEntryData{}, PreviewEntry{}, entry{}),
+				cmpopts.IgnoreFields(entry{}, "client"),
+			}
 			if diff := cmp.Diff(tt.want, got, opt); diff != "" {
 				t.Errorf("Manager.Preview() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestManager_PreviewAll(t *testing.T) {
+	client := newMockClient(&mockClient{
+		DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+			out := &cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []types.LogGroup{
+					{LogGroupName: aws.String("group0"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), RetentionInDays: aws.Int32(1), StoredBytes: aws.Int64(90)},
+					{LogGroupName: aws.String("group1"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(90)},
+				},
+			}
+			return out, nil
+		},
+	})
+	tests := []struct {
+		name       string
+		resultExpr *filterExpr
+		want       map[string]Action
+		wantErr    bool
+	}{
+		{
+			name:       "all entries",
+			resultExpr: nil,
+			want:       map[string]Action{"group0": ActionNoop, "group1": ActionUpdate},
+			wantErr:    false,
+		},
+		{
+			name: "with result filter",
+			resultExpr: func() *filterExpr {
+				expr, err := parseFilter(`action == "update"`)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return expr
+			}(),
+			want:    map[string]Action{"group1": ActionUpdate},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:        nowFunc,
+				client:       client,
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateOneDay,
+				resultExpr:   tt.resultExpr,
+				sem:          semaphore.NewWeighted(10),
+			}
+			got := make(map[string]Action)
+			var err error
+			for e, e2 := range man.PreviewAll(context.Background()) {
+				if e2 != nil {
+					err = e2
+					break
+				}
+				got[e.LogGroupName] = e.Action
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.PreviewAll() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Manager.PreviewAll() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package llcm
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+)
+
+// rollbackTarget represents a log group to be restored from the journal.
+type rollbackTarget struct {
+	prior      *JournalEntry // The earliest prior state recorded in the journal.
+	deleted    bool          // Whether any recorded change deleted the log group.
+	retention  bool          // Whether any recorded change touched the retention.
+	protection bool          // Whether any recorded change touched the deletion protection.
+}
+
+// Rollback restores the log groups to the prior state recorded in the journal.
+// If a log group was changed more than once, it is restored to the earliest recorded state.
+// Deleted log groups cannot be restored and are reported as skipped.
+func (man *Manager) Rollback(ctx context.Context, entries []*JournalEntry) (*ApplyEntryData, error) {
+	targets := newRollbackTargets(entries)
+	results := make([]*ApplyEntry, len(targets))
+	err := man.each(ctx, len(targets), func(ctx context.Context, i int) error {
+		t := targets[i]
+		e, err := man.describeLogGroup(ctx, t.prior.AccountID, t.prior.Region, t.prior.LogGroupName)
+		if err != nil {
+			results[i] = newApplyEntry(t.toEntry(nil), t.desired())
+			results[i].Error = err.Error()
+			return newEntryError(t.prior.AccountID, t.prior.Region, t.toEntry(nil), err)
+		}
+		results[i], err = man.rollback(ctx, t, e)
+		if err != nil {
+			return newEntryError(t.prior.AccountID, t.prior.Region, e, err)
+		}
+		return nil
+	})
+	data := &ApplyEntryData{
+		header:           applyEntryDataHeader,
+		entries:          make([]*ApplyEntry, 0, len(targets)),
+		maxPieChartItems: man.maxPieChartItems,
+	}
+	for _, e := range results {
+		if e != nil {
+			data.add(e)
+		}
+	}
+	if err != nil && !isErrors(err) {
+		return data, err
+	}
+	var errs Errors
+	if errors.As(err, &errs) {
+		errs.sort()
+		return data, errs
+	}
+	return data, nil
+}
+
+// rollback restores the retention and the deletion protection of the log group that differ from the prior state.
+// When the action fails, the error is also recorded in the result and the after state is left as before.
+func (man *Manager) rollback(ctx context.Context, t *rollbackTarget, current *entry) (*ApplyEntry, error) {
+	e := newApplyEntry(t.toEntry(current), t.desired())
+	switch {
+	case current == nil && t.deleted:
+		e.Action = ActionSkip
+		e.Error = "log group was deleted and cannot be restored"
+		return e, nil
+	case current == nil:
+		e.Action = ActionSkip
+		e.Error = "log group no longer exists"
+		return e, nil
+	}
+	start := man.now()
+	e.Action = ActionNoop
+	var err error
+	if t.retention && current.RetentionInDays != t.prior.RetentionInDays {
+		e.Action = ActionUpdate
+		e.RetentionAfter = t.prior.RetentionInDays
+		if t.prior.RetentionInDays == int64(DesiredStateInfinite) {
+			err = man.deleteRetentionPolicy(ctx, current.client, current.name, current.Region)
+		} else {
+			err = man.putRetentionPolicy(ctx, current.client, current.name, current.Region, int32(t.prior.RetentionInDays))
+		}
+	}
+	if err == nil && t.protection && current.DeletionProtection != t.prior.DeletionProtection {
+		e.Action = ActionUpdate
+		e.ProtectionAfter = t.prior.DeletionProtection
+		err = man.putLogGroupDeletionProtection(ctx, current.client, current.name, current.Region, t.prior.DeletionProtection)
+	}
+	e.Duration = man.now().Sub(start)
+	if err != nil {
+		e.Error = err.Error()
+		e.RetentionAfter = e.RetentionBefore
+		e.ProtectionAfter = e.ProtectionBefore
+		return e, err
+	}
+	e.Success = true
+	return e, nil
+}
+
+// newRollbackTargets groups the journal entries per log group in the order of the journal.
+func newRollbackTargets(entries []*JournalEntry) []*rollbackTarget {
+	var (
+		targets []*rollbackTarget
+		index   = make(map[string]*rollbackTarget, len(entries))
+	)
+	sorted := slices.Clone(entries)
+	slices.SortStableFunc(sorted, func(a, b *JournalEntry) int {
+		return cmp.Compare(a.Time.UnixNano(), b.Time.UnixNano())
+	})
+	for _, e := range sorted {
+		key := e.AccountID + "/" + e.Region + "/" + e.LogGroupName
+		t, ok := index[key]
+		if !ok {
+			t = &rollbackTarget{
+				prior: e,
+			}
+			index[key] = t
+			targets = append(targets, t)
+		}
+		switch e.DesiredState {
+		case DesiredStateZero:
+			// a forced delete disables the protection first, which is left disabled if the delete fails
+			t.deleted = true
+			t.protection = t.protection || e.DeletionProtection
+		case DesiredStateProtected, DesiredStateUnprotected:
+			t.protection = true
+		default:
+			t.retention = true
+		}
+	}
+	return targets
+}
+
+// desired returns the desired state that restores the prior retention.
+func (t *rollbackTarget) desired() DesiredState {
+	return DesiredState(t.prior.RetentionInDays)
+}
+
+// toEntry returns the current entry of the log group, or the entry of the prior state if it no longer exists.
+func (t *rollbackTarget) toEntry(current *entry) *entry {
+	if current != nil {
+		return current
+	}
+	return &entry{
+		LogGroupName:       t.prior.LogGroupName,
+		AccountID:          t.prior.AccountID,
+		Region:             t.prior.Region,
+		DeletionProtection: t.prior.DeletionProtection,
+		RetentionInDays:    t.prior.RetentionInDays,
+	}
+}
@@ -0,0 +1,223 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestManager_Rollback(t *testing.T) {
+	logGroups := []types.LogGroup{
+		{
+			LogGroupName:              aws.String("test-log-group-1"),
+			LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+			RetentionInDays:           aws.Int32(1),
+			DeletionProtectionEnabled: aws.Bool(false),
+		},
+		{
+			LogGroupName:              aws.String("test-log-group-2"),
+			LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+			DeletionProtectionEnabled: aws.Bool(false),
+		},
+	}
+	journal := []*JournalEntry{
+		{
+			Time:            mustTime("2025-04-01T00:00:00Z"),
+			LogGroupName:    "test-log-group-1",
+			AccountID:       "123456789012",
+			Region:          "us-east-1",
+			RetentionInDays: 365,
+			DesiredState:    DesiredStateOneMonth,
+		},
+		{
+			Time:            mustTime("2025-04-02T00:00:00Z"),
+			LogGroupName:    "test-log-group-1",
+			AccountID:       "123456789012",
+			Region:          "us-east-1",
+			RetentionInDays: 30,
+			DesiredState:    DesiredStateOneDay,
+		},
+		{
+			Time:               mustTime("2025-04-01T00:00:00Z"),
+			LogGroupName:       "test-log-group-2",
+			AccountID:          "123456789012",
+			Region:             "us-east-1",
+			RetentionInDays:    9999,
+			DeletionProtection: true,
+			DesiredState:       DesiredStateUnprotected,
+		},
+		{
+			Time:            mustTime("2025-04-01T00:00:00Z"),
+			LogGroupName:    "test-log-group-3",
+			AccountID:       "123456789012",
+			Region:          "us-east-1",
+			RetentionInDays: 7,
+			DesiredState:    DesiredStateZero,
+		},
+	}
+	put := func(_ context.Context, params *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+		if aws.ToInt32(params.RetentionInDays) != 365 {
+			return nil, fmt.Errorf("unexpected retention: %d", aws.ToInt32(params.RetentionInDays))
+		}
+		return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+	}
+	protect := func(_ context.Context, params *cloudwatchlogs.PutLogGroupDeletionProtectionInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogGroupDeletionProtectionOutput, error) {
+		if !aws.ToBool(params.DeletionProtectionEnabled) {
+			return nil, errors.New("unexpected protection")
+		}
+		return &cloudwatchlogs.PutLogGroupDeletionProtectionOutput{}, nil
+	}
+	type fields struct {
+		client          *Client
+		continueOnError bool
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		entries []*JournalEntry
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "restore",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc:             describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc:            put,
+					PutLogGroupDeletionProtectionFunc: protect,
+				}),
+			},
+			entries: journal,
+			want: []string{
+				"test-log-group-1 update true 1 365 false false ",
+				"test-log-group-2 update true 9999 9999 false true ",
+				"test-log-group-3 skip false 7 7 false false log group was deleted and cannot be restored",
+			},
+			wantErr: false,
+		},
+		{
+			name: "already restored",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(types.LogGroup{
+						LogGroupName:    aws.String("test-log-group-1"),
+						LogGroupArn:     aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+						RetentionInDays: aws.Int32(365),
+					}),
+				}),
+			},
+			entries: journal[:2],
+			want: []string{
+				"test-log-group-1 noop true 365 365 false false ",
+			},
+			wantErr: false,
+		},
+		{
+			name: "not exist",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(),
+				}),
+			},
+			entries: journal[:1],
+			want: []string{
+				"test-log-group-1 skip false 365 365 false false log group no longer exists",
+			},
+			wantErr: false,
+		},
+		{
+			name: "put retention policy returns error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(logGroups...),
+					PutRetentionPolicyFunc: func(_ context.Context, _ *cloudwatchlogs.PutRetentionPolicyInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+						return nil, errors.New("error")
+					},
+				}),
+			},
+			entries: journal[:1],
+			want: []string{
+				"test-log-group-1 update false 1 1 false false error",
+			},
+			wantErr: true,
+		},
+		{
+			name: "forced delete failed after unprotect",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: describeLogGroupsFunc(types.LogGroup{
+						LogGroupName:              aws.String("test-log-group-4"),
+						LogGroupArn:               aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-4"),
+						RetentionInDays:           aws.Int32(7),
+						DeletionProtectionEnabled: aws.Bool(false),
+					}),
+					PutLogGroupDeletionProtectionFunc: protect,
+				}),
+			},
+			entries: []*JournalEntry{
+				{
+					Time:               mustTime("2025-04-01T00:00:00Z"),
+					LogGroupName:       "test-log-group-4",
+					AccountID:          "123456789012",
+					Region:             "us-east-1",
+					RetentionInDays:    7,
+					DeletionProtection: true,
+					DesiredState:       DesiredStateZero,
+				},
+			},
+			want: []string{
+				"test-log-group-4 update true 7 7 false true ",
+			},
+			wantErr: false,
+		},
+		{
+			name: "continue on describe error",
+			fields: fields{
+				client: newMockClient(&mockClient{
+					DescribeLogGroupsFunc: func(_ context.Context, params *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+						if aws.ToString(params.LogGroupNamePrefix) == "test-log-group-1" {
+							return nil, errors.New("error")
+						}
+						return describeLogGroupsFunc(logGroups...)(context.Background(), params)
+					},
+					PutLogGroupDeletionProtectionFunc: protect,
+				}),
+				continueOnError: true,
+			},
+			entries: journal[:3],
+			want: []string{
+				"test-log-group-1 none false 365 365 false false error",
+				"test-log-group-2 update true 9999 9999 false true ",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:           nowFunc,
+				client:          tt.fields.client,
+				continueOnError: tt.fields.continueOnError,
+				sem:             semaphore.NewWeighted(10),
+			}
+			data, err := man.Rollback(context.Background(), tt.entries)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.Rollback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, e := range data.entries {
+				got = append(got, fmt.Sprintf("%s %s %t %d %d %t %t %s", e.LogGroupName, e.Action, e.Success, e.RetentionBefore, e.RetentionAfter, e.ProtectionBefore, e.ProtectionAfter, e.Error))
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
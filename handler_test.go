@@ -0,0 +1,120 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestManager_handle(t *testing.T) {
+	errRegion := errors.New("region error")
+	errEntry := errors.New("entry error")
+	client := newMockClient(&mockClient{
+		DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+			o := &cloudwatchlogs.Options{}
+			for _, fn := range optFns {
+				fn(o)
+			}
+			if o.Region == "us-west-2" {
+				return nil, errRegion
+			}
+			out := &cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []types.LogGroup{
+					{
+						LogGroupName: aws.String("test-log-group-1"),
+						LogGroupArn:  aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-1"),
+					},
+					{
+						LogGroupName: aws.String("test-log-group-2"),
+						LogGroupArn:  aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-2"),
+					},
+					{
+						LogGroupName: aws.String("test-log-group-3"),
+						LogGroupArn:  aws.String("arn:aws:logs:us-east-1:123456789012:log-group:test-log-group-3"),
+					},
+				},
+			}
+			return out, nil
+		},
+	})
+	handleFunc := func(n *atomic.Int32) func(*entry) error {
+		return func(e *entry) error {
+			if e.LogGroupName == "test-log-group-2" {
+				return errEntry
+			}
+			n.Add(1)
+			return nil
+		}
+	}
+	tests := []struct {
+		name            string
+		continueOnError bool
+		want            int32
+		wantErrs        []string
+		wantErr         error
+	}{
+		{
+			name:            "fail fast",
+			continueOnError: false,
+			want:            0,
+			wantErrs:        nil,
+			wantErr:         nil,
+		},
+		{
+			name:            "continue on error",
+			continueOnError: true,
+			want:            2,
+			wantErrs: []string{
+				"us-west-2: region error",
+				"123456789012: us-east-1: test-log-group-2: entry error",
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				clock:           nowFunc,
+				client:          client,
+				regions:         []string{"us-east-1", "us-west-2"},
+				continueOnError: tt.continueOnError,
+				sem:             semaphore.NewWeighted(10),
+			}
+			var n atomic.Int32
+			err := man.handle(context.Background(), handleFunc(&n))
+			if !tt.continueOnError {
+				if !errors.Is(err, errRegion) && !errors.Is(err, errEntry) {
+					t.Errorf("Manager.handle() error = %v, want underlying error", err)
+				}
+				if isErrors(err) {
+					t.Errorf("Manager.handle() error = %T, want not Errors", err)
+				}
+				return
+			}
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Manager.handle() error = %v, want Errors", err)
+			}
+			var got []string
+			for _, e := range errs {
+				got = append(got, e.Error())
+			}
+			if diff := cmp.Diff(got, tt.wantErrs); diff != "" {
+				t.Error(diff)
+			}
+			if !errors.Is(err, errEntry) {
+				t.Errorf("Manager.handle() error = %v, want to wrap %v", err, errEntry)
+			}
+			if n.Load() != tt.want {
+				t.Errorf("Manager.handle() = %d, want %d", n.Load(), tt.want)
+			}
+		})
+	}
+}
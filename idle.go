@@ -0,0 +1,65 @@
+package llcm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+// idleHeader is the header of the extra columns for the last event of the log group.
+var idleHeader = []string{
+	"LastEventAt",
+	"IdleDays",
+}
+
+// idleKeys is the list of the filter keys that refer to the last event of the log group.
+var idleKeys = []string{
+	"idle", "Idle", "IdleDays",
+	"lastEvent", "LastEvent", "lastEventAt", "LastEventAt",
+}
+
+// fetchIdle reports whether to describe the last events of the log groups, on request or for the filters.
+func (man *Manager) fetchIdle() bool {
+	return man.withIdle || man.refersTo(idleKeys...)
+}
+
+// describeLastEvent sets the time of the last event of the log group and the idle days up to now to the entry.
+// The log group without any event is taken as idle since it was created.
+func (man *Manager) describeLastEvent(ctx context.Context, e *entry, now time.Time) error {
+	key := limitKey{accountID: e.client.accountID, region: e.Region, api: apiDescribeLogStreams}
+	in := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogGroupName: e.name,
+		OrderBy:      types.OrderByLastEventTime,
+		Descending:   aws.Bool(true),
+		Limit:        aws.Int32(1),
+	}
+	var out *cloudwatchlogs.DescribeLogStreamsOutput
+	err := man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) (err error) {
+		out, err = e.client.DescribeLogStreams(ctx, in, opt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe log streams: %w", err)
+	}
+	e.idleColumns = true
+	e.LastEventAt = time.Time{}
+	e.IdleDays = e.ElapsedDays
+	if len(out.LogStreams) == 0 || out.LogStreams[0].LastEventTimestamp == nil {
+		return nil
+	}
+	e.LastEventAt = time.UnixMilli(aws.ToInt64(out.LogStreams[0].LastEventTimestamp))
+	e.IdleDays = elapsedDays(e.LastEventAt, now)
+	return nil
+}
+
+// lastEventAt returns the time of the last event in RFC3339, empty for the log group without any event.
+func (e *entry) lastEventAt() string {
+	if e.LastEventAt.IsZero() {
+		return ""
+	}
+	return e.LastEventAt.Format(time.RFC3339)
+}
@@ -0,0 +1,135 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestManager_fetchIdle(t *testing.T) {
+	policy := &Policy{Rules: []*PolicyRule{{Name: "idle", Filter: "idle > 180d", Desired: "delete"}}}
+	tests := []struct {
+		name string
+		man  *Manager
+		want bool
+	}{
+		{
+			name: "requested",
+			man:  &Manager{withIdle: true},
+			want: true,
+		},
+		{
+			name: "result filter",
+			man:  &Manager{resultRaw: "idle > 180d"},
+			want: true,
+		},
+		{
+			name: "policy",
+			man:  &Manager{policy: policy},
+			want: true,
+		},
+		{
+			name: "not needed",
+			man:  &Manager{filterRaw: "elapsed > 180d"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.man.fetchIdle(); got != tt.want {
+				t.Errorf("Manager.fetchIdle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_describeLastEvent(t *testing.T) {
+	tests := []struct {
+		name            string
+		client          *Client
+		wantLastEventAt string
+		wantIdleDays    int64
+		wantErr         bool
+	}{
+		{
+			name: "last event",
+			client: newMockClient(&mockClient{
+				DescribeLogStreamsFunc: func(_ context.Context, in *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+					if in.OrderBy != types.OrderByLastEventTime || !aws.ToBool(in.Descending) || aws.ToInt32(in.Limit) != 1 {
+						return nil, errors.New("unexpected input")
+					}
+					return &cloudwatchlogs.DescribeLogStreamsOutput{
+						LogStreams: []types.LogStream{{LastEventTimestamp: aws.Int64(mustUnixMilli("2025-03-01T12:00:00Z"))}},
+					}, nil
+				},
+			}),
+			wantLastEventAt: "2025-03-01T12:00:00Z",
+			wantIdleDays:    30,
+			wantErr:         false,
+		},
+		{
+			name: "no event",
+			client: newMockClient(&mockClient{
+				DescribeLogStreamsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+					return &cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: []types.LogStream{{}}}, nil
+				},
+			}),
+			wantLastEventAt: "",
+			wantIdleDays:    90,
+			wantErr:         false,
+		},
+		{
+			name: "api error",
+			client: newMockClient(&mockClient{
+				DescribeLogStreamsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogStreamsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+					return nil, errors.New("api error")
+				},
+			}),
+			wantLastEventAt: "",
+			wantIdleDays:    0,
+			wantErr:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{limiter: newLimiter()}
+			e := &entry{Region: "us-east-1", ElapsedDays: 90, name: aws.String("group0"), client: tt.client}
+			err := man.describeLastEvent(context.Background(), e, nowFunc())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.describeLastEvent() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got := e.lastEventAt(); got != tt.wantLastEventAt {
+				t.Errorf("Manager.describeLastEvent() lastEventAt = %v, want %v", got, tt.wantLastEventAt)
+			}
+			if e.IdleDays != tt.wantIdleDays {
+				t.Errorf("Manager.describeLastEvent() idleDays = %v, want %v", e.IdleDays, tt.wantIdleDays)
+			}
+		})
+	}
+}
+
+func TestEntry_extraValues(t *testing.T) {
+	e := &PreviewEntry{
+		entry: &entry{
+			LogGroupName: "group0",
+			LastEventAt:  mustTime("2025-03-01T00:00:00Z"),
+			IdleDays:     31,
+			Tags:         map[string]string{"env": "prod"},
+			tagColumns:   []string{"env"},
+			idleColumns:  true,
+		},
+	}
+	got := e.toTSV()
+	if diff := cmp.Diff([]string{"2025-03-01T00:00:00Z", "31", "prod"}, got[len(got)-3:]); diff != "" {
+		t.Errorf("PreviewEntry.toTSV() mismatch (-want +got):\n%s", diff)
+	}
+	if n := len(e.toInput()); n != len(got) {
+		t.Errorf("PreviewEntry.toInput() length = %v, want %v", n, len(got))
+	}
+}
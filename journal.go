@@ -0,0 +1,136 @@
+package llcm
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// JournalEntry represents the prior state of a log group recorded before apply changes it.
+type JournalEntry struct {
+	Time               time.Time    // The time when the entry was recorded.
+	LogGroupName       string       // The name of the log group.
+	AccountID          string       // The account ID that owns the log group.
+	Region             string       // The region that the log group belongs to.
+	RetentionInDays    int64        // The retention days before the change.
+	DeletionProtection bool         // Whether the log group was protected to deletion before the change.
+	DesiredState       DesiredState // The desired state that was applied.
+}
+
+// newJournalEntry creates a new journal entry from the current state of the log group at the specified time.
+func newJournalEntry(e *entry, desired DesiredState, now time.Time) *JournalEntry {
+	return &JournalEntry{
+		Time:               now,
+		LogGroupName:       e.LogGroupName,
+		AccountID:          e.AccountID,
+		Region:             e.Region,
+		RetentionInDays:    e.RetentionInDays,
+		DeletionProtection: e.DeletionProtection,
+		DesiredState:       desired,
+	}
+}
+
+// Journal represents an append-only journal of apply written as JSON Lines.
+type Journal struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJournal creates a new journal that writes to the specified writer.
+func NewJournal(w io.Writer) *Journal {
+	return &Journal{
+		w: w,
+	}
+}
+
+// OpenJournal opens the specified file for appending, creating it if it does not exist.
+// The journal should be closed after use.
+func OpenJournal(path string) (*Journal, error) {
+	f, err := os.OpenFile(filepath.Clean(path), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJournal(f), nil
+}
+
+// Write appends the entry to the journal as a line of JSON.
+func (j *Journal) Write(e *JournalEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.w.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is closable.
+func (j *Journal) Close() error {
+	if c, ok := j.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// LoadJournal loads the journal entries from the specified file.
+func LoadJournal(path string) ([]*JournalEntry, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return ParseJournal(f)
+}
+
+// ParseJournal parses the journal entries from JSON Lines.
+// Blank lines are ignored.
+func ParseJournal(r io.Reader) ([]*JournalEntry, error) {
+	var entries []*JournalEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		e := &JournalEntry{}
+		if err := json.Unmarshal(line, e); err != nil {
+			return nil, fmt.Errorf("invalid journal: line %d: %w", n, err)
+		}
+		if e.LogGroupName == "" || e.Region == "" {
+			return nil, fmt.Errorf("invalid journal: line %d: missing log group name or region", n)
+		}
+		if e.DesiredState == DesiredStateNone {
+			return nil, fmt.Errorf("invalid journal: line %d: missing desired state", n)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UnmarshalJSON parses the JSON representation of the JournalEntry.
+// A missing desired state is left as none, so that it is not taken as delete.
+func (e *JournalEntry) UnmarshalJSON(b []byte) error {
+	type alias JournalEntry
+	a := alias{
+		DesiredState: DesiredStateNone,
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*e = JournalEntry(a)
+	return nil
+}
@@ -0,0 +1,148 @@
+package llcm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJournal_Write(t *testing.T) {
+	var buf bytes.Buffer
+	j := NewJournal(&buf)
+	want := []*JournalEntry{
+		{
+			Time:               mustTime("2025-04-01T00:00:00Z"),
+			LogGroupName:       "group0",
+			AccountID:          "123456789012",
+			Region:             "ap-northeast-1",
+			RetentionInDays:    30,
+			DeletionProtection: false,
+			DesiredState:       DesiredStateZero,
+		},
+		{
+			Time:               mustTime("2025-04-01T00:00:00Z"),
+			LogGroupName:       "group1",
+			AccountID:          "210987654321",
+			Region:             "ap-northeast-2",
+			RetentionInDays:    9999,
+			DeletionProtection: true,
+			DesiredState:       DesiredStateUnprotected,
+		},
+	}
+	for _, e := range want {
+		if err := j.Write(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := strings.Count(buf.String(), "\n"); n != len(want) {
+		t.Errorf("Journal.Write() = %d lines, want %d", n, len(want))
+	}
+	got, err := ParseJournal(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+	if err := j.Close(); err != nil {
+		t.Errorf("Journal.Close() error = %v", err)
+	}
+}
+
+func TestOpenJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	for range 2 {
+		j, err := OpenJournal(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Write(newJournalEntry(&entry{LogGroupName: "group0", Region: "ap-northeast-1", RetentionInDays: 30}, DesiredStateOneDay, nowFunc())); err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := LoadJournal(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("LoadJournal() = %d entries, want %d", len(got), 2)
+	}
+	if _, err := OpenJournal(filepath.Join(path, "unknown")); err == nil {
+		t.Error("OpenJournal() error = nil, want error")
+	}
+	if _, err := LoadJournal(filepath.Join(t.TempDir(), "unknown.jsonl")); err == nil {
+		t.Error("LoadJournal() error = nil, want error")
+	}
+}
+
+func TestParseJournal(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			input:   `{"LogGroupName":"group0","Region":"ap-northeast-1","RetentionInDays":30,"DesiredState":"1day"}` + "\n\n" + `{"LogGroupName":"group0","Region":"ap-northeast-1","RetentionInDays":1,"DesiredState":"delete"}` + "\n",
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			input:   "",
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "invalid json",
+			input:   `{"LogGroupName":`,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "missing region",
+			input:   `{"LogGroupName":"group0","DesiredState":"1day"}`,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "missing desired state",
+			input:   `{"LogGroupName":"group0","Region":"ap-northeast-1"}`,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "invalid desired state",
+			input:   `{"LogGroupName":"group0","Region":"ap-northeast-1","DesiredState":"unknown"}`,
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseJournal(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseJournal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseJournal() = %d entries, want %d", len(got), tt.want)
+			}
+		})
+	}
+}
+
+// errWriter is helper writer that always fails.
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, os.ErrClosed
+}
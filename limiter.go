@@ -0,0 +1,247 @@
+package llcm
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"golang.org/x/time/rate"
+)
+
+var _ aws.RetryerV2 = (*limitedRetryer)(nil)
+
+const (
+	apiDescribeLogGroups             = "DescribeLogGroups"
+	apiPutRetentionPolicy            = "PutRetentionPolicy"
+	apiDeleteRetentionPolicy         = "DeleteRetentionPolicy"
+	apiDeleteLogGroup                = "DeleteLogGroup"
+	apiPutLogGroupDeletionProtection = "PutLogGroupDeletionProtection"
+	apiListTagsForResource           = "ListTagsForResource"
+	apiTagResource                   = "TagResource"
+	apiDescribeLogStreams            = "DescribeLogStreams"
+	apiGetFunction                   = "GetFunction"
+	apiBatchGetProjects              = "BatchGetProjects"
+	apiDescribeTaskDefinition        = "DescribeTaskDefinition"
+	apiGetRestApi                    = "GetRestApi"
+)
+
+const (
+	// defaultQuota is the transactions per second for the API without a published quota.
+	defaultQuota = 5
+
+	// initialConcurrency is the initial number of concurrent API calls for each account and region.
+	initialConcurrency = 8
+
+	// minConcurrency is the minimum number of concurrent API calls for each account and region.
+	minConcurrency = 1
+
+	// maxConcurrency is the maximum number of concurrent API calls for each account and region.
+	maxConcurrency = 64
+)
+
+// apiQuotas is the map of the APIs to the published CloudWatch Logs quotas
+// in transactions per second for each account and region.
+var apiQuotas = map[string]float64{
+	apiDescribeLogGroups:             10,
+	apiPutRetentionPolicy:            5,
+	apiDeleteRetentionPolicy:         5,
+	apiDeleteLogGroup:                10,
+	apiPutLogGroupDeletionProtection: 5,
+	apiDescribeLogStreams:            25,
+}
+
+// limitKey represents the scope of a limit. The API is empty for the concurrency.
+// The service is empty for CloudWatch Logs.
+type limitKey struct {
+	accountID string
+	region    string
+	service   string
+	api       string
+}
+
+// limiter represents the token-bucket rate limiters for each account, region and API
+// and the adaptive concurrency for each account and region.
+type limiter struct {
+	mu       sync.Mutex
+	buckets  map[limitKey]*rate.Limiter
+	adaptive map[limitKey]*concurrency
+}
+
+// newLimiter creates a new limiter.
+func newLimiter() *limiter {
+	return &limiter{
+		buckets:  make(map[limitKey]*rate.Limiter),
+		adaptive: make(map[limitKey]*concurrency),
+	}
+}
+
+// bucket returns the rate limiter for the account, region and API.
+func (l *limiter) bucket(key limitKey) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	if !ok {
+		tps, ok := apiQuotas[key.api]
+		if !ok {
+			tps = defaultQuota
+		}
+		b = rate.NewLimiter(rate.Limit(tps), int(math.Ceil(tps)))
+		l.buckets[key] = b
+	}
+	return b
+}
+
+// concurrency returns the adaptive concurrency for the account, region and service.
+func (l *limiter) concurrency(key limitKey) *concurrency {
+	key.api = ""
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	c, ok := l.adaptive[key]
+	if !ok {
+		c = newConcurrency(initialConcurrency, minConcurrency, maxConcurrency)
+		l.adaptive[key] = c
+	}
+	return c
+}
+
+// call calls the API within the adaptive concurrency for the account and region.
+// Each attempt of the call waits for the rate limit of the API and adjusts the concurrency by its result.
+// The retryer is used as the base retryer if specified, otherwise the retryer of the client is used.
+// Without the limiter, the API is called as is.
+func (l *limiter) call(ctx context.Context, key limitKey, base *Retryer, fn func(func(*cloudwatchlogs.Options)) error) error {
+	return limitedCall(ctx, l, key, base, fn, func(o *cloudwatchlogs.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+}
+
+// limitedCall calls the API of any service in the same way as limiter.call.
+// The fields function returns the region and the retryer in the options of the client for the service.
+func limitedCall[O any](ctx context.Context, l *limiter, key limitKey, base *Retryer, fn func(func(*O)) error, fields func(*O) (*string, *aws.Retryer)) error {
+	opt := func(o *O) {
+		region, retryer := fields(o)
+		*region = key.region
+		if base != nil {
+			*retryer = base
+		}
+		if l != nil {
+			*retryer = &limitedRetryer{
+				Retryer: *retryer,
+				limiter: l,
+				key:     key,
+			}
+		}
+	}
+	if l == nil {
+		return fn(opt)
+	}
+	c := l.concurrency(key)
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return fn(opt)
+}
+
+// limitedRetryer represents a retryer that waits for the rate limit before each attempt
+// and reports the result of the attempt to the adaptive concurrency.
+type limitedRetryer struct {
+	aws.Retryer
+
+	limiter *limiter
+	key     limitKey
+}
+
+// GetAttemptToken waits for the rate limit and returns the attempt token.
+func (r *limitedRetryer) GetAttemptToken(ctx context.Context) (func(error) error, error) {
+	if err := r.limiter.bucket(r.key).Wait(ctx); err != nil {
+		return nil, err
+	}
+	release := r.Retryer.GetInitialToken()
+	if v2, ok := r.Retryer.(aws.RetryerV2); ok {
+		var err error
+		if release, err = v2.GetAttemptToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+	c := r.limiter.concurrency(r.key)
+	return func(err error) error {
+		switch {
+		case isThrottlingError(err):
+			c.decrease()
+		case err == nil:
+			c.increase()
+		}
+		return release(err)
+	}, nil
+}
+
+// concurrency represents the number of concurrent calls adjusted in the additive-increase/multiplicative-decrease manner.
+// The limit increases by one for each limit of successful calls and halves on throttling.
+type concurrency struct {
+	mu       sync.Mutex
+	limit    float64
+	min      float64
+	max      float64
+	inflight int
+	wake     chan struct{}
+}
+
+// newConcurrency creates a new concurrency with the initial, minimum and maximum limits.
+func newConcurrency(initial, minimum, maximum int) *concurrency {
+	return &concurrency{
+		limit: float64(initial),
+		min:   float64(minimum),
+		max:   float64(maximum),
+		wake:  make(chan struct{}),
+	}
+}
+
+// acquire waits until the number of calls in flight is below the limit, or the context is done.
+func (c *concurrency) acquire(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		if c.inflight < int(c.limit) {
+			c.inflight++
+			c.mu.Unlock()
+			return nil
+		}
+		wake := c.wake
+		c.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// release releases the call in flight and wakes up the waiters.
+func (c *concurrency) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inflight--
+	c.notify()
+}
+
+// increase increases the limit additively.
+func (c *concurrency) increase() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = min(c.limit+1/c.limit, c.max)
+	c.notify()
+}
+
+// decrease decreases the limit multiplicatively.
+func (c *concurrency) decrease() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.limit = max(c.limit/2, c.min)
+}
+
+// notify wakes up all the waiters. It must be called with the lock held.
+func (c *concurrency) notify() {
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
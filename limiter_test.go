@@ -0,0 +1,206 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go"
+)
+
+func TestLimiter_bucket(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       limitKey
+		wantLimit float64
+		wantBurst int
+	}{
+		{
+			name:      "describe log groups",
+			key:       limitKey{accountID: "123456789012", region: "us-east-1", api: apiDescribeLogGroups},
+			wantLimit: 10,
+			wantBurst: 10,
+		},
+		{
+			name:      "put retention policy",
+			key:       limitKey{accountID: "123456789012", region: "us-east-1", api: apiPutRetentionPolicy},
+			wantLimit: 5,
+			wantBurst: 5,
+		},
+		{
+			name:      "unknown api",
+			key:       limitKey{accountID: "123456789012", region: "us-east-1", api: "Unknown"},
+			wantLimit: defaultQuota,
+			wantBurst: defaultQuota,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter()
+			got := l.bucket(tt.key)
+			if float64(got.Limit()) != tt.wantLimit {
+				t.Errorf("limiter.bucket() limit = %v, want %v", got.Limit(), tt.wantLimit)
+			}
+			if got.Burst() != tt.wantBurst {
+				t.Errorf("limiter.bucket() burst = %v, want %v", got.Burst(), tt.wantBurst)
+			}
+			if l.bucket(tt.key) != got {
+				t.Errorf("limiter.bucket() is not reused for the same key")
+			}
+			other := tt.key
+			other.region = "us-west-2"
+			if l.bucket(other) == got {
+				t.Errorf("limiter.bucket() is shared across regions")
+			}
+		})
+	}
+}
+
+func TestLimiter_call(t *testing.T) {
+	key := limitKey{accountID: "123456789012", region: "us-east-1", api: apiDeleteLogGroup}
+	tests := []struct {
+		name        string
+		limiter     *limiter
+		wantLimited bool
+	}{
+		{
+			name:        "with limiter",
+			limiter:     newLimiter(),
+			wantLimited: true,
+		},
+		{
+			name:        "without limiter",
+			limiter:     nil,
+			wantLimited: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &cloudwatchlogs.Options{}
+			err := tt.limiter.call(context.Background(), key, NewRetryer(defaultMaxRetryAttempts, time.Second), func(opt func(*cloudwatchlogs.Options)) error {
+				opt(o)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if o.Region != key.region {
+				t.Errorf("limiter.call() region = %v, want %v", o.Region, key.region)
+			}
+			_, limited := o.Retryer.(*limitedRetryer)
+			if limited != tt.wantLimited {
+				t.Errorf("limiter.call() limited = %v, want %v", limited, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestLimitedCall(t *testing.T) {
+	key := limitKey{accountID: "123456789012", region: "us-east-1", service: ownerLambda, api: apiGetFunction}
+	l := newLimiter()
+	o := &lambda.Options{}
+	err := limitedCall(context.Background(), l, key, NewRetryer(defaultMaxRetryAttempts, time.Second), func(opt func(*lambda.Options)) error {
+		opt(o)
+		return nil
+	}, func(o *lambda.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Region != key.region {
+		t.Errorf("limitedCall() region = %v, want %v", o.Region, key.region)
+	}
+	if _, ok := o.Retryer.(*limitedRetryer); !ok {
+		t.Errorf("limitedCall() retryer = %T, want *limitedRetryer", o.Retryer)
+	}
+	logs := limitKey{accountID: key.accountID, region: key.region, api: apiDescribeLogGroups}
+	if l.concurrency(key) == l.concurrency(logs) {
+		t.Errorf("limiter.concurrency() is shared across services")
+	}
+}
+
+func TestLimitedRetryer_GetAttemptToken(t *testing.T) {
+	key := limitKey{accountID: "123456789012", region: "us-east-1", api: apiPutRetentionPolicy}
+	tests := []struct {
+		name string
+		err  error
+		want float64
+	}{
+		{
+			name: "success",
+			err:  nil,
+			want: initialConcurrency + 1.0/initialConcurrency,
+		},
+		{
+			name: "throttling",
+			err:  &smithy.GenericAPIError{Code: "ThrottlingException"},
+			want: initialConcurrency / 2,
+		},
+		{
+			name: "other error",
+			err:  errors.New("unexpected error"),
+			want: initialConcurrency,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter()
+			r := &limitedRetryer{
+				Retryer: NewRetryer(defaultMaxRetryAttempts, time.Second),
+				limiter: l,
+				key:     key,
+			}
+			release, err := r.GetAttemptToken(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := release(tt.err); err != nil {
+				t.Fatal(err)
+			}
+			if got := l.concurrency(key).limit; got != tt.want {
+				t.Errorf("limitedRetryer.GetAttemptToken() limit = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	c := newConcurrency(4, 1, 5)
+	c.decrease()
+	c.decrease()
+	c.decrease()
+	if c.limit != 1 {
+		t.Errorf("concurrency.decrease() limit = %v, want %v", c.limit, 1)
+	}
+	for range 100 {
+		c.increase()
+	}
+	if c.limit != 5 {
+		t.Errorf("concurrency.increase() limit = %v, want %v", c.limit, 5)
+	}
+}
+
+func TestConcurrency_acquire(t *testing.T) {
+	c := newConcurrency(1, 1, 1)
+	if err := c.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("concurrency.acquire() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	done := make(chan error)
+	go func() {
+		done <- c.acquire(context.Background())
+	}()
+	c.release()
+	if err := <-done; err != nil {
+		t.Errorf("concurrency.acquire() error = %v", err)
+	}
+}
@@ -15,18 +15,12 @@ import (
 // TestMain is the entry point of the test.
 func TestMain(m *testing.M) {
 	var (
-		originalMaxPieChartItems = setMaxPieChartItems(3)
 		originalMaxBarChartItems = setMaxBarChartItems(3)
 		localTimeZone            = setTimeZone(time.UTC)
 	)
-	nowFunc = func() time.Time {
-		return mustTime("2025-04-01T00:00:00Z")
-	}
 	defer func() {
-		setMaxPieChartItems(originalMaxPieChartItems)
 		setMaxBarChartItems(originalMaxBarChartItems)
 		setTimeZone(localTimeZone)
-		nowFunc = time.Now
 		if err := removeChartFiles(); err != nil {
 			panic(err)
 		}
@@ -49,11 +43,9 @@ func removeChartFiles() error {
 	return nil
 }
 
-// setMaxPieChartItems is helper function to set MaxPieChartItems and return the original value.
-func setMaxPieChartItems(n int) (original int) {
-	original = MaxPieChartItems
-	MaxPieChartItems = n
-	return original
+// nowFunc is the clock of the manager in tests.
+func nowFunc() time.Time {
+	return mustTime("2025-04-01T00:00:00Z")
 }
 
 // setMaxBarChartItems is helper function to set MaxBarChartItems and return the original value.
@@ -90,13 +82,25 @@ func mustUnixMilli(s string) int64 {
 	return t.UnixMilli()
 }
 
+// mustPolicy is helper function to parse policy string to *Policy.
+// It panics if the string is not a valid policy.
+func mustPolicy(s string) *Policy {
+	p, err := ParsePolicy([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
 // listEntryData is a test data for ListEntryData.
 var listEntryData = ListEntryData{
-	header: listEntryDataHeader,
+	header:           listEntryDataHeader,
+	maxPieChartItems: 3,
 	entries: []*ListEntry{
 		{
 			entry: &entry{
 				LogGroupName:       "group0",
+				AccountID:          "123456789012",
 				Region:             "ap-northeast-1",
 				Class:              types.LogGroupClassStandard,
 				CreatedAt:          mustTime("2025-01-01T00:00:00Z"),
@@ -110,6 +114,7 @@ var listEntryData = ListEntryData{
 		{
 			entry: &entry{
 				LogGroupName:       "group1",
+				AccountID:          "210987654321",
 				Region:             "ap-northeast-2",
 				Class:              types.LogGroupClassInfrequentAccess,
 				CreatedAt:          mustTime("2024-04-01T00:00:00Z"),
@@ -125,16 +130,19 @@ var listEntryData = ListEntryData{
 
 // previewEntryData is a test data for PreviewEntryData.
 var previewEntryData = PreviewEntryData{
-	header: previewEntryDataHeader,
+	header:      previewEntryDataHeader,
+	previewedAt: mustTime("2025-04-01T00:00:00Z"),
 	entries: []*PreviewEntry{
 		{
 			BytesPerDay:     0,
 			DesiredState:    0,
+			Action:          ActionDelete,
 			ReductionInDays: 0,
 			ReducibleBytes:  0,
 			RemainingBytes:  0,
 			entry: &entry{
 				LogGroupName:       "group0",
+				AccountID:          "123456789012",
 				Region:             "ap-northeast-1",
 				Class:              types.LogGroupClassStandard,
 				CreatedAt:          mustTime("2025-01-01T00:00:00Z"),
@@ -148,11 +156,69 @@ var previewEntryData = PreviewEntryData{
 		{
 			BytesPerDay:     100,
 			DesiredState:    9999,
+			Rule:            "rule1",
+			Action:          ActionUpdate,
 			ReductionInDays: 100,
 			ReducibleBytes:  100,
 			RemainingBytes:  100,
 			entry: &entry{
 				LogGroupName:       "group1",
+				AccountID:          "210987654321",
+				Region:             "ap-northeast-2",
+				Class:              types.LogGroupClassInfrequentAccess,
+				CreatedAt:          mustTime("2024-04-01T00:00:00Z"),
+				DeletionProtection: true,
+				ElapsedDays:        365,
+				RetentionInDays:    30,
+				StoredBytes:        2048,
+				name:               aws.String("group1"),
+			},
+		},
+	},
+}
+
+// applyEntryData is a test data for ApplyEntryData.
+var applyEntryData = ApplyEntryData{
+	TotalStoredBytes: 3072,
+	TotalApplied:     1,
+	TotalFailed:      1,
+	header:           applyEntryDataHeader,
+	maxPieChartItems: 3,
+	entries: []*ApplyEntry{
+		{
+			DesiredState:     DesiredStateOneDay,
+			Action:           ActionUpdate,
+			RetentionBefore:  30,
+			RetentionAfter:   1,
+			ProtectionBefore: false,
+			ProtectionAfter:  false,
+			Success:          true,
+			Duration:         120 * time.Millisecond,
+			entry: &entry{
+				LogGroupName:    "group0",
+				AccountID:       "123456789012",
+				Region:          "ap-northeast-1",
+				Class:           types.LogGroupClassStandard,
+				CreatedAt:       mustTime("2025-01-01T00:00:00Z"),
+				ElapsedDays:     90,
+				RetentionInDays: 30,
+				StoredBytes:     1024,
+				name:            aws.String("group0"),
+			},
+		},
+		{
+			DesiredState:     DesiredStateZero,
+			Action:           ActionDelete,
+			RetentionBefore:  30,
+			RetentionAfter:   30,
+			ProtectionBefore: true,
+			ProtectionAfter:  true,
+			Success:          false,
+			Error:            "api error",
+			Duration:         80 * time.Millisecond,
+			entry: &entry{
+				LogGroupName:       "group1",
+				AccountID:          "210987654321",
 				Region:             "ap-northeast-2",
 				Class:              types.LogGroupClassInfrequentAccess,
 				CreatedAt:          mustTime("2024-04-01T00:00:00Z"),
@@ -185,3 +251,13 @@ var errPreviewEntryData = PreviewEntryData{
 		},
 	},
 }
+
+// errApplyEntryData is a test data for ApplyEntryData of error case.
+var errApplyEntryData = ApplyEntryData{
+	header: previewEntryDataHeader,
+	entries: []*ApplyEntry{
+		{
+			entry: &entry{},
+		},
+	},
+}
@@ -3,16 +3,23 @@ package llcm
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"runtime"
+	"strings"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/nekrassov01/filter"
 	"golang.org/x/sync/semaphore"
 )
 
 // NumWorker is the number of workers for concurrent processing.
+//
+// Deprecated: Use WithConcurrency instead. It no longer affects the behavior.
 var NumWorker = int64(runtime.NumCPU()*2 + 1)
 
+// defaultConcurrency is the default number of log groups processed concurrently.
+const defaultConcurrency = 16
+
 type (
 	filterExpr   = filter.Expr   // filterExpr is a type alias for filter.Expr.
 	filterTarget = filter.Target // filterTarget is a type alias for filter.Target.
@@ -20,25 +27,64 @@ type (
 
 // Manager represents a log group lifecycle manager.
 type Manager struct {
-	client             *Client             // The client for CloudWatch Logs.
-	regions            []string            // The list of target regions.
-	desiredState       DesiredState        // The desired state of the log group.
-	desiredStateNative *int32              // The desired state with the native type.
-	deletionProtection *bool               // Whether to enable log group deletion protection.
-	filterExpr         *filterExpr         // The expressions for filtering log groups.
-	filterRaw          string              // The raw filter string.
-	sem                *semaphore.Weighted // The weighted semaphore for concurrent processing.
+	client           *Client             // The client for CloudWatch Logs.
+	accounts         []*Client           // The clients for each target account.
+	regions          []string            // The list of target regions.
+	desiredState     DesiredState        // The desired state of the log group.
+	round            Round               // The direction to snap the desired retention that is not allowed.
+	policy           *Policy             // The policy that resolves the desired state for each log group.
+	mode             Mode                // The direction in which the retention is allowed to change.
+	forceUnprotect   bool                // Whether to disable the deletion protection to delete protected log groups.
+	plan             *Plan               // The saved plan that apply executes exactly.
+	allowDrift       bool                // Whether to skip drifted log groups in the plan instead of failing.
+	journal          *Journal            // The journal to record the prior state before apply changes it.
+	dryRun           bool                // Whether to go through apply without any write call.
+	tagApplied       bool                // Whether to tag the log groups changed by apply.
+	assumeYes        bool                // Whether to apply without confirmation.
+	confirm          ConfirmFunc         // The function to confirm the changes before apply.
+	maxChanges       int64               // The maximum number of log groups to be changed, or 0 for no limit.
+	maxDeletedBytes  int64               // The maximum total stored bytes of log groups to be deleted, or 0 for no limit.
+	continueOnError  bool                // Whether to collect errors and finish the remaining work instead of failing fast.
+	filterExpr       *filterExpr         // The expressions for filtering log groups.
+	filterRaw        string              // The raw filter string.
+	pushdown         *pushdown           // The conditions of the filter pushed down to DescribeLogGroups.
+	resultExpr       *filterExpr         // The expressions for filtering log groups by the simulated results.
+	resultRaw        string              // The raw result filter string.
+	withTags         bool                // Whether to fetch the tags of log groups.
+	withIdle         bool                // Whether to fetch the last events of log groups.
+	withOrphan       bool                // Whether to check the owners of log groups.
+	tagColumns       []string            // The tag keys rendered as the extra columns.
+	desiredTagKey    string              // The tag key that declares the desired state of each log group.
+	defaultState     DesiredState        // The desired state used when the tag does not declare it.
+	hasDefault       bool                // Whether the default desired state is set.
+	sem              *semaphore.Weighted // The weighted semaphore for concurrent processing.
+	limiter          *limiter            // The rate limits and the adaptive concurrency of API calls.
+	retryer          *Retryer            // The retryer applied to all API calls.
+	clock            func() time.Time    // The function that returns the current time.
+	logger           *slog.Logger        // The logger for the debug logs.
+	maxPieChartItems int                 // The maximum number of items in a pie chart.
 }
 
 // NewManager creates a new manager for log group lifecycle management.
-func NewManager(client *Client) *Manager {
-	return &Manager{
-		client:             client,
-		regions:            DefaultRegions,
-		desiredState:       DesiredStateNone,
-		deletionProtection: aws.Bool(false),
-		sem:                semaphore.NewWeighted(NumWorker),
+// The options are applied in order, and the first error is returned.
+func NewManager(client *Client, opts ...Option) (*Manager, error) {
+	man := &Manager{
+		client:           client,
+		regions:          DefaultRegions,
+		desiredState:     DesiredStateNone,
+		sem:              semaphore.NewWeighted(defaultConcurrency),
+		limiter:          newLimiter(),
+		retryer:          NewRetryer(defaultMaxRetryAttempts, defaultMaxRetryDelay),
+		clock:            time.Now,
+		logger:           slog.New(slog.DiscardHandler),
+		maxPieChartItems: defaultMaxPieChartItems,
+	}
+	for _, opt := range opts {
+		if err := opt(man); err != nil {
+			return nil, err
+		}
 	}
+	return man, nil
 }
 
 // SetRegion sets the specified regions.
@@ -55,44 +101,432 @@ func (man *Manager) SetRegion(regions []string) error {
 	return nil
 }
 
+// SetAccount sets the clients for each target account.
+// If no clients are specified, the default client is used.
+func (man *Manager) SetAccount(clients []*Client) error {
+	if len(clients) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(clients))
+	for _, client := range clients {
+		if client == nil {
+			return fmt.Errorf("nil client")
+		}
+		if _, ok := seen[client.accountID]; ok {
+			return fmt.Errorf("duplicate account: %q", client.accountID)
+		}
+		seen[client.accountID] = struct{}{}
+	}
+	man.accounts = clients
+	return nil
+}
+
 // SetDesiredState sets the desired state.
+// The retention that is not allowed is snapped in the direction of the round if it is set.
+// The desired state from-tag or from-tag:<key> resolves it from the tag of each log group, llcm:retention by default.
 func (man *Manager) SetDesiredState(desired string) error {
-	d, err := ParseDesiredState(desired)
+	key, ok, err := parseFromTag(desired)
+	if err != nil {
+		return err
+	}
+	if ok {
+		man.desiredTagKey = key
+		man.desiredState = DesiredStateNone
+		return nil
+	}
+	d, err := ParseDesiredStateWithRound(desired, man.round)
 	if err != nil {
 		return err
 	}
 	man.desiredState = d
-	man.desiredStateNative = aws.Int32(int32(man.desiredState))
-	if d == DesiredStateProtected {
-		man.deletionProtection = aws.Bool(true)
+	return nil
+}
+
+// SetDefaultState sets the desired state used for the log groups whose tags do not declare it.
+// It requires the desired state resolved from the tags, so it should be set after the desired state.
+func (man *Manager) SetDefaultState(desired string) error {
+	if desired == "" {
+		return nil
+	}
+	if man.desiredTagKey == "" {
+		return fmt.Errorf("default desired state requires the desired state from tag")
 	}
+	d, err := ParseDesiredStateWithRound(desired, man.round)
+	if err != nil {
+		return err
+	}
+	man.defaultState = d
+	man.hasDefault = true
+	return nil
+}
+
+// SetRound sets the direction to snap the desired retention that is not allowed to the nearest allowed one.
+// It should be set before the desired state.
+func (man *Manager) SetRound(round string) error {
+	r, err := ParseRound(round)
+	if err != nil {
+		return err
+	}
+	man.round = r
+	return nil
+}
+
+// SetMode sets the direction in which the retention is allowed to change.
+// The log groups whose retention would change in the other direction are skipped.
+func (man *Manager) SetMode(mode string) error {
+	m, err := ParseMode(mode)
+	if err != nil {
+		return err
+	}
+	man.mode = m
+	return nil
+}
+
+// SetForceUnprotect sets whether to disable the deletion protection to delete protected log groups.
+// Without it, deleting a protected log group is skipped.
+func (man *Manager) SetForceUnprotect(force bool) {
+	man.forceUnprotect = force
+}
+
+// SetPolicy loads the policy from the specified file and sets it.
+// When the policy is set, the desired state is resolved for each log group by the first matching rule.
+func (man *Manager) SetPolicy(path string) error {
+	if path == "" {
+		return nil
+	}
+	p, err := LoadPolicy(path)
+	if err != nil {
+		return err
+	}
+	man.policy = p
+	return nil
+}
+
+// SetPlan loads the plan from the specified file and sets it.
+// When the plan is set, apply acts only on the log groups in the plan in the mode of the plan.
+// Whether to force unprotecting is also taken from the plan.
+func (man *Manager) SetPlan(path string) error {
+	if path == "" {
+		return nil
+	}
+	p, err := LoadPlan(path)
+	if err != nil {
+		return err
+	}
+	man.plan = p
+	man.mode = p.Mode
+	man.forceUnprotect = p.ForceUnprotect
+	return nil
+}
+
+// SetAllowDrift sets whether to skip drifted log groups in the plan instead of failing.
+func (man *Manager) SetAllowDrift(allow bool) {
+	man.allowDrift = allow
+}
+
+// SetJournal sets the journal to record the prior state of each log group before apply changes it.
+func (man *Manager) SetJournal(j *Journal) {
+	man.journal = j
+}
+
+// SetDryRun sets whether to go through apply without any write call.
+func (man *Manager) SetDryRun(dryRun bool) {
+	man.dryRun = dryRun
+}
+
+// SetTagApplied sets whether to tag the log groups changed by apply with the managed-by, applied-at and desired-state tags.
+func (man *Manager) SetTagApplied(tagApplied bool) {
+	man.tagApplied = tagApplied
+}
+
+// SetAssumeYes sets whether to apply without confirmation.
+func (man *Manager) SetAssumeYes(yes bool) {
+	man.assumeYes = yes
+}
+
+// SetConfirm sets the function to confirm the changes before apply.
+// Without it, apply fails unless yes is assumed.
+func (man *Manager) SetConfirm(fn ConfirmFunc) {
+	man.confirm = fn
+}
+
+// SetMaxChanges sets the maximum number of log groups that apply can change.
+// Zero means no limit.
+func (man *Manager) SetMaxChanges(n int64) error {
+	if n < 0 {
+		return fmt.Errorf("invalid max changes: %d", n)
+	}
+	man.maxChanges = n
+	return nil
+}
+
+// SetMaxDeletedBytes sets the maximum total stored bytes of log groups that apply can delete.
+// Zero means no limit.
+func (man *Manager) SetMaxDeletedBytes(n int64) error {
+	if n < 0 {
+		return fmt.Errorf("invalid max deleted bytes: %d", n)
+	}
+	man.maxDeletedBytes = n
+	return nil
+}
+
+// SetContinueOnError sets whether to collect errors per region and per log group and finish the remaining work.
+// When any error occurred, the operations return the results with Errors.
+func (man *Manager) SetContinueOnError(continueOnError bool) {
+	man.continueOnError = continueOnError
+}
+
+// SetWithTags sets whether to fetch the tags of log groups with ListTagsForResource.
+// The tags are also fetched when selected as columns or referred to by the filters, e.g. tag.env == "prod".
+func (man *Manager) SetWithTags(withTags bool) {
+	man.withTags = withTags
+}
+
+// SetWithIdle sets whether to fetch the last event of log groups with DescribeLogStreams.
+// The last event is also fetched when referred to by the filters, e.g. idle > 180d.
+func (man *Manager) SetWithIdle(withIdle bool) {
+	man.withIdle = withIdle
+}
+
+// SetWithOrphan sets whether to check if the resources that own log groups still exist,
+// e.g. the Lambda function of /aws/lambda/<function>.
+// The owners are also checked when referred to by the filters, e.g. orphaned == true.
+func (man *Manager) SetWithOrphan(withOrphan bool) {
+	man.withOrphan = withOrphan
+}
+
+// SetTagColumns sets the tag keys rendered as the extra columns of the tables.
+func (man *Manager) SetTagColumns(keys []string) error {
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("empty tag key")
+		}
+	}
+	man.tagColumns = keys
 	return nil
 }
 
 // SetFilter sets the filter expressions.
+// The conditions on the name and the class of log groups implied by the filter are pushed down to DescribeLogGroups.
 func (man *Manager) SetFilter(raw string) error {
 	if raw == "" {
 		return nil
 	}
-	expr, err := filter.Parse(raw)
+	if key, ok := findResultKey(raw); ok {
+		return fmt.Errorf("failed to parse filter: %q is a simulated result: use the result filter instead", key)
+	}
+	expr, err := parseFilter(raw)
 	if err != nil {
 		return fmt.Errorf("failed to parse filter: %w", err)
 	}
 	man.filterExpr = expr
 	man.filterRaw = raw
+	man.pushdown = newPushdown(raw)
 	return nil
 }
 
+// SetResultFilter sets the filter expressions evaluated against the simulated results of the desired state.
+// It is evaluated after the filter, and the log groups that do not match are neither previewed nor applied.
+func (man *Manager) SetResultFilter(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	expr, err := parseFilter(raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse result filter: %w", err)
+	}
+	man.resultExpr = expr
+	man.resultRaw = raw
+	return nil
+}
+
+// resolve returns the desired state for the entry and the name of the rule that matched it.
+// If the policy is set and no rule matches, ok is false and the entry should be left alone.
+func (man *Manager) resolve(e *entry) (desired DesiredState, rule string, ok bool, err error) {
+	if man.policy == nil {
+		if man.desiredTagKey == "" {
+			return man.desiredState, "", true, nil
+		}
+		d, rule, issue := man.desiredStateFromTag(e)
+		switch {
+		case issue == nil:
+			return d, rule, true, nil
+		case man.hasDefault:
+			return man.defaultState, "default", true, nil
+		default:
+			return DesiredStateNone, "", false, nil
+		}
+	}
+	r, err := man.policy.match(e)
+	if err != nil {
+		return DesiredStateNone, "", false, err
+	}
+	if r == nil {
+		return DesiredStateNone, "", false, nil
+	}
+	return r.desiredState, r.Name, true, nil
+}
+
+// filters returns the raw filter strings of the filter, the result filter and the policy rules.
+func (man *Manager) filters() []string {
+	s := []string{man.filterRaw, man.resultRaw}
+	if man.policy != nil {
+		for _, rule := range man.policy.Rules {
+			s = append(s, rule.Filter)
+		}
+	}
+	return s
+}
+
+// refersTo reports whether any of the filters, including the policy rules, refers to any of the keys.
+// The key ending with a dot stands for the keys prefixed with it, e.g. tag. for tag.env.
+func (man *Manager) refersTo(keys ...string) bool {
+	for _, raw := range man.filters() {
+		for _, tok := range tokenizeFilter(raw) {
+			for _, key := range keys {
+				if strings.HasSuffix(key, ".") {
+					if len(tok) > len(key) && strings.HasPrefix(tok, key) {
+						return true
+					}
+					continue
+				}
+				if tok == key {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// clients returns the clients for the target accounts.
+func (man *Manager) clients() []*Client {
+	if len(man.accounts) == 0 {
+		return []*Client{man.client}
+	}
+	return man.accounts
+}
+
+// clientFor returns the client for the specified account.
+// Without target accounts, the default client is used for any account.
+func (man *Manager) clientFor(accountID string) (*Client, error) {
+	if len(man.accounts) == 0 {
+		return man.client, nil
+	}
+	for _, client := range man.accounts {
+		if client.accountID == accountID {
+			return client, nil
+		}
+	}
+	return nil, fmt.Errorf("no client for account: %q", accountID)
+}
+
+// now returns the current time of the clock of the manager.
+func (man *Manager) now() time.Time {
+	if man.clock == nil {
+		return time.Now()
+	}
+	return man.clock()
+}
+
+// debug writes the debug log with the logger of the manager if any.
+func (man *Manager) debug(msg string, args ...any) {
+	if man.logger == nil {
+		return
+	}
+	man.logger.Debug(msg, args...)
+}
+
+// tagIssue returns the issue of the tag of the entry if the desired state is resolved from the tag
+// and the tag does not declare it, or nil otherwise.
+func (man *Manager) tagIssue(e *entry) *TagIssue {
+	if man.policy != nil || man.desiredTagKey == "" {
+		return nil
+	}
+	_, _, issue := man.desiredStateFromTag(e)
+	return issue
+}
+
+// RetryStats returns the number of retries and throttled attempts of the API calls so far.
+func (man *Manager) RetryStats() RetryStats {
+	if man.retryer == nil {
+		return RetryStats{}
+	}
+	return man.retryer.Stats()
+}
+
 // String returns the string representation of the manager.
 func (man *Manager) String() string {
+	var accounts []string
+	for _, client := range man.accounts {
+		accounts = append(accounts, client.accountID)
+	}
+	var planned int
+	if man.plan != nil {
+		planned = len(man.plan.Entries)
+	}
+	var defaultState string
+	if man.hasDefault {
+		defaultState = man.defaultState.String()
+	}
+	// the desired state is reported by its source when resolved for each log group
+	desiredState := man.desiredState.String()
+	switch {
+	case man.plan != nil:
+		desiredState = "plan"
+	case man.policy != nil:
+		desiredState = "policy"
+	case man.desiredTagKey != "":
+		desiredState = fromTagPrefix + ":" + man.desiredTagKey
+	}
 	s := struct {
-		Regions      []string `json:"regions"`
-		DesiredState string   `json:"desiredState"`
-		Filter       string   `json:"filter"`
+		Accounts        []string  `json:"accounts,omitempty"`
+		Regions         []string  `json:"regions"`
+		DesiredState    string    `json:"desiredState"`
+		DefaultState    string    `json:"defaultState,omitempty"`
+		Filter          string    `json:"filter"`
+		Pushdown        *pushdown `json:"pushdown,omitempty"`
+		ResultFilter    string    `json:"resultFilter,omitempty"`
+		WithTags        bool      `json:"withTags,omitempty"`
+		WithIdle        bool      `json:"withIdle,omitempty"`
+		WithOrphan      bool      `json:"withOrphan,omitempty"`
+		TagColumns      []string  `json:"tagColumns,omitempty"`
+		Policy          *Policy   `json:"policy,omitempty"`
+		Mode            string    `json:"mode"`
+		ForceUnprotect  bool      `json:"forceUnprotect,omitempty"`
+		Plan            int       `json:"plan,omitempty"`
+		AllowDrift      bool      `json:"allowDrift,omitempty"`
+		Journal         bool      `json:"journal,omitempty"`
+		DryRun          bool      `json:"dryRun,omitempty"`
+		TagApplied      bool      `json:"tagApplied,omitempty"`
+		AssumeYes       bool      `json:"assumeYes,omitempty"`
+		MaxChanges      int64     `json:"maxChanges,omitempty"`
+		MaxDeletedBytes int64     `json:"maxDeletedBytes,omitempty"`
+		ContinueOnError bool      `json:"continueOnError,omitempty"`
 	}{
-		Regions:      man.regions,
-		DesiredState: man.desiredState.String(),
-		Filter:       man.filterRaw,
+		Accounts:        accounts,
+		Regions:         man.regions,
+		DesiredState:    desiredState,
+		DefaultState:    defaultState,
+		Filter:          man.filterRaw,
+		Pushdown:        man.pushdown,
+		ResultFilter:    man.resultRaw,
+		WithTags:        man.fetchTags(),
+		WithIdle:        man.fetchIdle(),
+		WithOrphan:      man.fetchOrphan(),
+		TagColumns:      man.tagColumns,
+		Policy:          man.policy,
+		Mode:            man.mode.String(),
+		ForceUnprotect:  man.forceUnprotect,
+		Plan:            planned,
+		AllowDrift:      man.allowDrift,
+		Journal:         man.journal != nil,
+		DryRun:          man.dryRun,
+		TagApplied:      man.tagApplied,
+		AssumeYes:       man.assumeYes,
+		MaxChanges:      man.maxChanges,
+		MaxDeletedBytes: man.maxDeletedBytes,
+		ContinueOnError: man.continueOnError,
 	}
 	b, _ := json.Marshal(s)
 	return string(b)
@@ -1,22 +1,28 @@
 package llcm
 
 import (
+	"log/slog"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/sync/semaphore"
 )
 
 func TestNewManager(t *testing.T) {
+	retryer := NewRetryer(3, time.Second)
 	type args struct {
 		client *Client
+		opts   []Option
 	}
 	tests := []struct {
-		name string
-		args args
-		want *Manager
+		name    string
+		args    args
+		want    *Manager
+		wantErr bool
 	}{
 		{
 			name: "empty client",
@@ -24,13 +30,16 @@ func TestNewManager(t *testing.T) {
 				client: &Client{},
 			},
 			want: &Manager{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       -1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:           &Client{},
+				regions:          DefaultRegions,
+				desiredState:     -1,
+				filterExpr:       nil,
+				sem:              semaphore.NewWeighted(defaultConcurrency),
+				limiter:          newLimiter(),
+				retryer:          NewRetryer(defaultMaxRetryAttempts, defaultMaxRetryDelay),
+				maxPieChartItems: defaultMaxPieChartItems,
 			},
+			wantErr: false,
 		},
 		{
 			name: "nil client",
@@ -38,18 +47,121 @@ func TestNewManager(t *testing.T) {
 				client: nil,
 			},
 			want: &Manager{
-				client:             nil,
-				regions:            DefaultRegions,
-				desiredState:       -1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:           nil,
+				regions:          DefaultRegions,
+				desiredState:     -1,
+				filterExpr:       nil,
+				sem:              semaphore.NewWeighted(defaultConcurrency),
+				limiter:          newLimiter(),
+				retryer:          NewRetryer(defaultMaxRetryAttempts, defaultMaxRetryDelay),
+				maxPieChartItems: defaultMaxPieChartItems,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with options",
+			args: args{
+				client: &Client{},
+				opts: []Option{
+					WithRegions("ap-northeast-1", "ap-northeast-2"),
+					WithConcurrency(4),
+					WithRetryer(retryer),
+					WithClock(nowFunc),
+					WithLogger(slog.New(slog.DiscardHandler)),
+					WithMaxPieChartItems(3),
+				},
+			},
+			want: &Manager{
+				client:           &Client{},
+				regions:          []string{"ap-northeast-1", "ap-northeast-2"},
+				desiredState:     -1,
+				filterExpr:       nil,
+				sem:              semaphore.NewWeighted(4),
+				limiter:          newLimiter(),
+				retryer:          retryer,
+				maxPieChartItems: 3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid region",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithRegions("invalid")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid concurrency",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithConcurrency(0)},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "nil retryer",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithRetryer(nil)},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "nil clock",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithClock(nil)},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "nil logger",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithLogger(nil)},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid filter",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithFilter("name ==")},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "invalid max pie chart items",
+			args: args{
+				client: &Client{},
+				opts:   []Option{WithMaxPieChartItems(0)},
 			},
+			want:    nil,
+			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewManager(tt.args.client); !reflect.DeepEqual(got, tt.want) {
+			got, err := NewManager(tt.args.client, tt.args.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewManager() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.clock == nil || got.logger == nil {
+				t.Errorf("NewManager() clock = %p, logger = %v", got.clock, got.logger)
+			}
+			got.clock, got.logger = nil, nil
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewManager() = %v, want %v", got, tt.want)
 			}
 		})
@@ -58,13 +170,11 @@ func TestNewManager(t *testing.T) {
 
 func TestManager_SetRegion(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		deletionProtection *bool
-		filterExpr         *filterExpr
-		sem                *semaphore.Weighted
+		client       *Client
+		regions      []string
+		desiredState DesiredState
+		filterExpr   *filterExpr
+		sem          *semaphore.Weighted
 	}
 	type args struct {
 		regions []string
@@ -78,12 +188,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "valid regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{"us-west-1", "eu-central-1"},
@@ -93,12 +202,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "empty regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{},
@@ -108,12 +216,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "nil regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: nil,
@@ -123,12 +230,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "with unsupported region",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{"us-west-1", "invalid-region"},
@@ -138,12 +244,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "with duplicate regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{"us-west-1", "us-west-1"},
@@ -153,12 +258,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "with uppercase regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{"US-WEST-1", "eu-central-1"},
@@ -168,12 +272,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "default regions",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: DefaultRegions,
@@ -183,12 +286,11 @@ func TestManager_SetRegion(t *testing.T) {
 		{
 			name: "one region",
 			fields: fields{
-				client:             &Client{},
-				regions:            DefaultRegions,
-				desiredState:       1,
-				deletionProtection: aws.Bool(false),
-				filterExpr:         nil,
-				sem:                semaphore.NewWeighted(NumWorker),
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				regions: []string{"us-east-1"},
@@ -199,12 +301,11 @@ func TestManager_SetRegion(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				filterExpr:         tt.fields.filterExpr,
-				sem:                tt.fields.sem,
+				client:       tt.fields.client,
+				regions:      tt.fields.regions,
+				desiredState: tt.fields.desiredState,
+				filterExpr:   tt.fields.filterExpr,
+				sem:          tt.fields.sem,
 			}
 			if err := man.SetRegion(tt.args.regions); (err != nil) != tt.wantErr {
 				t.Errorf("Manager.SetRegion() error = %v, wantErr %v", err, tt.wantErr)
@@ -213,14 +314,77 @@ func TestManager_SetRegion(t *testing.T) {
 	}
 }
 
+func TestManager_SetAccount(t *testing.T) {
+	type args struct {
+		clients []*Client
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "multiple accounts",
+			args: args{
+				clients: []*Client{
+					{accountID: "111111111111"},
+					{accountID: "222222222222"},
+				},
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "no accounts",
+			args: args{
+				clients: nil,
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "duplicate accounts",
+			args: args{
+				clients: []*Client{
+					{accountID: "111111111111"},
+					{accountID: "111111111111"},
+				},
+			},
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name: "nil client",
+			args: args{
+				clients: []*Client{nil},
+			},
+			want:    1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{
+				client: &Client{},
+			}
+			if err := man.SetAccount(tt.args.clients); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(man.clients()); got != tt.want {
+				t.Errorf("Manager.clients() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestManager_SetDesiredState(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		filterExpr         *filterExpr
-		sem                *semaphore.Weighted
+		client       *Client
+		regions      []string
+		desiredState DesiredState
+		filterExpr   *filterExpr
+		sem          *semaphore.Weighted
 	}
 	type args struct {
 		desired string
@@ -238,7 +402,7 @@ func TestManager_SetDesiredState(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 0,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				desired: DesiredStateOneDay.String(),
@@ -252,7 +416,7 @@ func TestManager_SetDesiredState(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 0,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				desired: DesiredStateProtected.String(),
@@ -266,23 +430,50 @@ func TestManager_SetDesiredState(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 0,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				desired: DesiredStateNone.String(),
 			},
 			wantErr: true,
 		},
+		{
+			name: "from tag",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 0,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				desired: "from-tag:retention",
+			},
+			wantErr: false,
+		},
+		{
+			name: "from tag with empty key",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 0,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				desired: "from-tag:",
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				filterExpr:         tt.fields.filterExpr,
-				sem:                tt.fields.sem,
+				client:       tt.fields.client,
+				regions:      tt.fields.regions,
+				desiredState: tt.fields.desiredState,
+				filterExpr:   tt.fields.filterExpr,
+				sem:          tt.fields.sem,
 			}
 			if err := man.SetDesiredState(tt.args.desired); (err != nil) != tt.wantErr {
 				t.Errorf("Manager.SetRetentionInDays() error = %v, wantErr %v", err, tt.wantErr)
@@ -291,15 +482,304 @@ func TestManager_SetDesiredState(t *testing.T) {
 	}
 }
 
+func TestManager_SetPolicy(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(valid, []byte("rules:\n  - desired: 1year\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("rules:\n  - desired: unknown\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				path: valid,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "empty",
+			args: args{
+				path: "",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "invalid",
+			args: args{
+				path: invalid,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{}
+			if err := man.SetPolicy(tt.args.path); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := man.policy != nil; got != tt.want {
+				t.Errorf("Manager.SetPolicy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SetPlan(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "plan.json")
+	if err := os.WriteFile(valid, []byte(`{"Mode":"shorten-only","ForceUnprotect":true,"Entries":[{"LogGroupName":"group0","Region":"us-east-1","DesiredState":"1year"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"Entries":[{"LogGroupName":"group0","Region":"us-east-1"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				path: valid,
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "empty",
+			args: args{
+				path: "",
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "invalid",
+			args: args{
+				path: invalid,
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{}
+			if err := man.SetPlan(tt.args.path); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := man.plan != nil; got != tt.want {
+				t.Errorf("Manager.SetPlan() = %v, want %v", got, tt.want)
+			}
+			if got := man.mode == ModeShortenOnly && man.forceUnprotect; got != tt.want {
+				t.Errorf("Manager.SetPlan() mode and force unprotect taken = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SetRound(t *testing.T) {
+	tests := []struct {
+		name    string
+		round   string
+		desired string
+		want    DesiredState
+		wantErr bool
+	}{
+		{
+			name:    "up",
+			round:   "up",
+			desired: "100",
+			want:    DesiredStateFourMonths,
+			wantErr: false,
+		},
+		{
+			name:    "down",
+			round:   "down",
+			desired: "P100D",
+			want:    DesiredStateThreeMonths,
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			round:   "nearest",
+			desired: "100",
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{desiredState: DesiredStateNone}
+			err := man.SetRound(tt.round)
+			if err == nil {
+				err = man.SetDesiredState(tt.desired)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetRound() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if man.desiredState != tt.want {
+				t.Errorf("Manager.SetRound() = %v, want %v", man.desiredState, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SetMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		want    Mode
+		wantErr bool
+	}{
+		{
+			name:    "shorten only",
+			mode:    "shorten-only",
+			want:    ModeShortenOnly,
+			wantErr: false,
+		},
+		{
+			name:    "lengthen only",
+			mode:    "lengthen-only",
+			want:    ModeLengthenOnly,
+			wantErr: false,
+		},
+		{
+			name:    "unknown",
+			mode:    "unknown",
+			want:    ModeExact,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{}
+			if err := man.SetMode(tt.mode); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if man.mode != tt.want {
+				t.Errorf("Manager.SetMode() = %v, want %v", man.mode, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SetMaxChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int64
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "limit",
+			n:       10,
+			want:    10,
+			wantErr: false,
+		},
+		{
+			name:    "no limit",
+			n:       0,
+			want:    0,
+			wantErr: false,
+		},
+		{
+			name:    "negative",
+			n:       -1,
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{}
+			if err := man.SetMaxChanges(tt.n); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetMaxChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if man.maxChanges != tt.want {
+				t.Errorf("Manager.SetMaxChanges() = %v, want %v", man.maxChanges, tt.want)
+			}
+			if err := man.SetMaxDeletedBytes(tt.n); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetMaxDeletedBytes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if man.maxDeletedBytes != tt.want {
+				t.Errorf("Manager.SetMaxDeletedBytes() = %v, want %v", man.maxDeletedBytes, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_SetResultFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "basic",
+			raw:     `reducibleBytes > 1024`,
+			want:    `reducibleBytes > 1024`,
+			wantErr: false,
+		},
+		{
+			name:    "empty",
+			raw:     ``,
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name:    "error",
+			raw:     `[`,
+			want:    ``,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{}
+			if err := man.SetResultFilter(tt.raw); (err != nil) != tt.wantErr {
+				t.Errorf("Manager.SetResultFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if man.resultRaw != tt.want {
+				t.Errorf("Manager.SetResultFilter() = %v, want %v", man.resultRaw, tt.want)
+			}
+			if (man.resultExpr != nil) != (tt.want != "") {
+				t.Errorf("Manager.SetResultFilter() expr = %v, want set %v", man.resultExpr, tt.want != "")
+			}
+		})
+	}
+}
+
 func TestManager_SetFilter(t *testing.T) {
 	type fields struct {
-		client             *Client
-		regions            []string
-		desiredState       DesiredState
-		desiredStateNative *int32
-		filterExpr         *filterExpr
-		filterRaw          string
-		sem                *semaphore.Weighted
+		client       *Client
+		regions      []string
+		desiredState DesiredState
+		filterExpr   *filterExpr
+		filterRaw    string
+		sem          *semaphore.Weighted
 	}
 	type args struct {
 		filter string
@@ -317,13 +797,83 @@ func TestManager_SetFilter(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 1,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				filter: `name == "error-log"`,
 			},
 			wantErr: false,
 		},
+		{
+			name: "simulated result",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				filter: `name == "error-log" && action == "update"`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulated reason",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				filter: `reason != ""`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "simulated result key quoted",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				filter: `name == "action"`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unit literals",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				filter: `bytes > 10GB && elapsed > 1y && retention == infinite`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown unit",
+			fields: fields{
+				client:       &Client{},
+				regions:      DefaultRegions,
+				desiredState: 1,
+				filterExpr:   nil,
+				sem:          semaphore.NewWeighted(defaultConcurrency),
+			},
+			args: args{
+				filter: `bytes > 10XB`,
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty",
 			fields: fields{
@@ -331,7 +881,7 @@ func TestManager_SetFilter(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 1,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				filter: ``,
@@ -345,7 +895,7 @@ func TestManager_SetFilter(t *testing.T) {
 				regions:      DefaultRegions,
 				desiredState: 1,
 				filterExpr:   nil,
-				sem:          semaphore.NewWeighted(NumWorker),
+				sem:          semaphore.NewWeighted(defaultConcurrency),
 			},
 			args: args{
 				filter: `[`,
@@ -356,13 +906,12 @@ func TestManager_SetFilter(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				client:             tt.fields.client,
-				regions:            tt.fields.regions,
-				desiredState:       tt.fields.desiredState,
-				desiredStateNative: tt.fields.desiredStateNative,
-				filterExpr:         tt.fields.filterExpr,
-				filterRaw:          tt.fields.filterRaw,
-				sem:                tt.fields.sem,
+				client:       tt.fields.client,
+				regions:      tt.fields.regions,
+				desiredState: tt.fields.desiredState,
+				filterExpr:   tt.fields.filterExpr,
+				filterRaw:    tt.fields.filterRaw,
+				sem:          tt.fields.sem,
 			}
 			err := man.SetFilter(tt.args.filter)
 			if (err != nil) != tt.wantErr {
@@ -374,9 +923,12 @@ func TestManager_SetFilter(t *testing.T) {
 
 func TestManager_String(t *testing.T) {
 	type fields struct {
-		regions      []string
-		desiredState DesiredState
-		filterRaw    string
+		regions       []string
+		desiredState  DesiredState
+		desiredTagKey string
+		policy        *Policy
+		filterRaw     string
+		pushdown      *pushdown
 	}
 	tests := []struct {
 		name   string
@@ -390,7 +942,7 @@ func TestManager_String(t *testing.T) {
 				desiredState: 7,
 				filterRaw:    `name == "logname"`,
 			},
-			want: `{"regions":["ap-northeast-1","ap-northeast-2","ap-northeast-3","ap-south-1","ap-southeast-1","ap-southeast-2","ca-central-1","eu-central-1","eu-west-1","eu-west-2","eu-west-3","eu-north-1","sa-east-1","us-east-1","us-east-2","us-west-1","us-west-2"],"desiredState":"1week","filter":"name == \"logname\""}`,
+			want: `{"regions":["ap-northeast-1","ap-northeast-2","ap-northeast-3","ap-south-1","ap-southeast-1","ap-southeast-2","ca-central-1","eu-central-1","eu-west-1","eu-west-2","eu-west-3","eu-north-1","sa-east-1","us-east-1","us-east-2","us-west-1","us-west-2"],"desiredState":"1week","filter":"name == \"logname\"","mode":"exact"}`,
 		},
 		{
 			name: "empty manager",
@@ -399,15 +951,46 @@ func TestManager_String(t *testing.T) {
 				desiredState: 0,
 				filterRaw:    "",
 			},
-			want: `{"regions":null,"desiredState":"delete","filter":""}`,
+			want: `{"regions":null,"desiredState":"delete","filter":"","mode":"exact"}`,
+		},
+		{
+			name: "with pushdown",
+			fields: fields{
+				regions:      []string{"us-east-1"},
+				desiredState: 7,
+				filterRaw:    `name =~ "^/aws/lambda/"`,
+				pushdown:     &pushdown{NamePrefix: "/aws/lambda/"},
+			},
+			want: `{"regions":["us-east-1"],"desiredState":"1week","filter":"name =~ \"^/aws/lambda/\"","pushdown":{"namePrefix":"/aws/lambda/"},"mode":"exact"}`,
+		},
+		{
+			name: "desired state from tag",
+			fields: fields{
+				regions:       []string{"us-east-1"},
+				desiredState:  DesiredStateNone,
+				desiredTagKey: "llcm:retention",
+			},
+			want: `{"regions":["us-east-1"],"desiredState":"from-tag:llcm:retention","filter":"","withTags":true,"mode":"exact"}`,
+		},
+		{
+			name: "desired state from policy",
+			fields: fields{
+				regions:      []string{"us-east-1"},
+				desiredState: DesiredStateNone,
+				policy:       &Policy{},
+			},
+			want: `{"regions":["us-east-1"],"desiredState":"policy","filter":"","policy":{"rules":null},"mode":"exact"}`,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			man := &Manager{
-				regions:      tt.fields.regions,
-				desiredState: tt.fields.desiredState,
-				filterRaw:    tt.fields.filterRaw,
+				regions:       tt.fields.regions,
+				desiredState:  tt.fields.desiredState,
+				desiredTagKey: tt.fields.desiredTagKey,
+				policy:        tt.fields.policy,
+				filterRaw:     tt.fields.filterRaw,
+				pushdown:      tt.fields.pushdown,
 			}
 			if got := man.String(); got != tt.want {
 				t.Errorf("Manager.String() = %v, want %v", got, tt.want)
@@ -418,3 +1001,56 @@ func TestManager_String(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_refersTo(t *testing.T) {
+	tests := []struct {
+		name string
+		man  *Manager
+		keys []string
+		want bool
+	}{
+		{
+			name: "filter",
+			man:  &Manager{filterRaw: `name =~ "^/aws/" && idle > 180d`},
+			keys: idleKeys,
+			want: true,
+		},
+		{
+			name: "result filter",
+			man:  &Manager{resultRaw: `orphaned == true`},
+			keys: orphanKeys,
+			want: true,
+		},
+		{
+			name: "prefix",
+			man:  &Manager{filterRaw: `tag.env == "prod"`},
+			keys: []string{tagKeyPrefix},
+			want: true,
+		},
+		{
+			name: "prefix only",
+			man:  &Manager{filterRaw: `tag. == "prod"`},
+			keys: []string{tagKeyPrefix},
+			want: false,
+		},
+		{
+			name: "quoted",
+			man:  &Manager{filterRaw: `name == "idle" || name == "tag.env"`},
+			keys: append([]string{tagKeyPrefix}, idleKeys...),
+			want: false,
+		},
+		{
+			name: "empty",
+			man:  &Manager{},
+			keys: idleKeys,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.man.refersTo(tt.keys...); got != tt.want {
+				t.Errorf("Manager.refersTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
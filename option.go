@@ -0,0 +1,84 @@
+package llcm
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// Option represents an option to configure the manager.
+type Option func(*Manager) error
+
+// WithRegions sets the target regions.
+// If no regions are specified, the default regions are used.
+func WithRegions(regions ...string) Option {
+	return func(man *Manager) error {
+		return man.SetRegion(regions)
+	}
+}
+
+// WithConcurrency sets the number of log groups processed concurrently.
+func WithConcurrency(n int64) Option {
+	return func(man *Manager) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid concurrency: %d", n)
+		}
+		man.sem = semaphore.NewWeighted(n)
+		return nil
+	}
+}
+
+// WithRetryer sets the retryer applied to all API calls.
+func WithRetryer(r *Retryer) Option {
+	return func(man *Manager) error {
+		if r == nil {
+			return fmt.Errorf("nil retryer")
+		}
+		man.retryer = r
+		return nil
+	}
+}
+
+// WithClock sets the function that returns the current time.
+// It is used for the elapsed days, the journal, the plan and the elapsed time of the operations.
+func WithClock(clock func() time.Time) Option {
+	return func(man *Manager) error {
+		if clock == nil {
+			return fmt.Errorf("nil clock")
+		}
+		man.clock = clock
+		return nil
+	}
+}
+
+// WithLogger sets the logger for the debug logs.
+func WithLogger(logger *slog.Logger) Option {
+	return func(man *Manager) error {
+		if logger == nil {
+			return fmt.Errorf("nil logger")
+		}
+		man.logger = logger
+		return nil
+	}
+}
+
+// WithFilter sets the filter expressions.
+func WithFilter(raw string) Option {
+	return func(man *Manager) error {
+		return man.SetFilter(raw)
+	}
+}
+
+// WithMaxPieChartItems sets the maximum number of items in a pie chart.
+// The rest of the items are grouped as "others".
+func WithMaxPieChartItems(n int) Option {
+	return func(man *Manager) error {
+		if n <= 0 {
+			return fmt.Errorf("invalid max pie chart items: %d", n)
+		}
+		man.maxPieChartItems = n
+		return nil
+	}
+}
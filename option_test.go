@@ -0,0 +1,31 @@
+package llcm
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithClock(t *testing.T) {
+	man, err := NewManager(&Client{}, WithClock(nowFunc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := man.now(), nowFunc(); !got.Equal(want) {
+		t.Errorf("Manager.now() = %v, want %v", got, want)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	man, err := NewManager(&Client{}, WithLogger(logger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	man.debug("test message", "key", "value")
+	if got := buf.String(); !strings.Contains(got, "msg=\"test message\" key=value") {
+		t.Errorf("Manager.debug() = %q, want to contain the message", got)
+	}
+}
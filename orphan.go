@@ -0,0 +1,150 @@
+package llcm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+)
+
+const (
+	// ownerLambda is the kind of the owner for the log group of the Lambda function, e.g. /aws/lambda/<function>.
+	ownerLambda = "lambda"
+
+	// ownerCodeBuild is the kind of the owner for the log group of the CodeBuild project, e.g. /aws/codebuild/<project>.
+	ownerCodeBuild = "codebuild"
+
+	// ownerECS is the kind of the owner for the log group of the ECS task definition family, e.g. /ecs/<family>.
+	ownerECS = "ecs"
+
+	// ownerAPIGateway is the kind of the owner for the execution log group of the API Gateway REST API,
+	// e.g. API-Gateway-Execution-Logs_<api-id>/<stage>.
+	ownerAPIGateway = "apigateway"
+)
+
+// orphanHeader is the header of the extra column for whether the log group is orphaned.
+var orphanHeader = []string{
+	"Orphaned",
+}
+
+// orphanKeys is the list of the filter keys that refer to whether the log group is orphaned.
+var orphanKeys = []string{"orphaned", "Orphaned"}
+
+// LambdaAPI represents an interface for Lambda to check whether the function exists.
+type LambdaAPI interface {
+	GetFunction(ctx context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error)
+}
+
+// CodeBuildAPI represents an interface for CodeBuild to check whether the project exists.
+type CodeBuildAPI interface {
+	BatchGetProjects(ctx context.Context, params *codebuild.BatchGetProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.BatchGetProjectsOutput, error)
+}
+
+// ECSAPI represents an interface for ECS to check whether the task definition family has an active revision.
+type ECSAPI interface {
+	DescribeTaskDefinition(ctx context.Context, params *ecs.DescribeTaskDefinitionInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error)
+}
+
+// APIGatewayAPI represents an interface for API Gateway to check whether the REST API exists.
+type APIGatewayAPI interface {
+	GetRestApi(ctx context.Context, params *apigateway.GetRestApiInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApiOutput, error)
+}
+
+// Owners represents the clients to check whether the resources that own the log groups still exist.
+// The log groups whose owner has no client are not taken as orphaned.
+type Owners struct {
+	Lambda     LambdaAPI
+	CodeBuild  CodeBuildAPI
+	ECS        ECSAPI
+	APIGateway APIGatewayAPI
+}
+
+// owner represents the resource that owns the log group, inferred from the name of the log group.
+type owner struct {
+	kind   string // The kind of the resource.
+	region string // The region that the resource belongs to.
+	name   string // The name or the ID of the resource.
+}
+
+// ownerOf returns the resource that owns the log group by the naming convention of the service.
+// It reports false if the log group does not follow any of them.
+func ownerOf(name, region string) (owner, bool) {
+	if s, ok := strings.CutPrefix(name, "/aws/lambda/"); ok {
+		// the replicas of Lambda@Edge are named /aws/lambda/<region>.<function> after the region of the function,
+		// while the name of the function itself can contain dots
+		if r, fn, ok := strings.Cut(s, "."); ok {
+			if _, ok := allowedRegions[r]; ok {
+				return owner{kind: ownerLambda, region: r, name: fn}, fn != ""
+			}
+		}
+		return owner{kind: ownerLambda, region: region, name: s}, s != ""
+	}
+	if s, ok := strings.CutPrefix(name, "/aws/codebuild/"); ok {
+		return owner{kind: ownerCodeBuild, region: region, name: s}, s != "" && !strings.Contains(s, "/")
+	}
+	if s, ok := strings.CutPrefix(name, "/ecs/"); ok {
+		return owner{kind: ownerECS, region: region, name: s}, s != "" && !strings.Contains(s, "/")
+	}
+	if s, ok := strings.CutPrefix(name, "API-Gateway-Execution-Logs_"); ok {
+		id, _, _ := strings.Cut(s, "/")
+		return owner{kind: ownerAPIGateway, region: region, name: id}, id != ""
+	}
+	return owner{}, false
+}
+
+// fetchOrphan reports whether to check the owners of the log groups, on request or for the filters.
+func (man *Manager) fetchOrphan() bool {
+	return man.withOrphan || man.refersTo(orphanKeys...)
+}
+
+// checkOrphan sets whether the resource that owns the log group no longer exists to the entry.
+func (man *Manager) checkOrphan(ctx context.Context, e *entry) error {
+	e.orphanColumns = true
+	e.Orphaned = false
+	o, ok := ownerOf(e.LogGroupName, e.Region)
+	if !ok {
+		return nil
+	}
+	owners := e.client.ownerClients()
+	if owners == nil {
+		return nil
+	}
+	var (
+		key    = limitKey{accountID: e.client.accountID, region: o.region}
+		exists bool
+		err    error
+	)
+	switch o.kind {
+	case ownerLambda:
+		if owners.Lambda == nil {
+			return nil
+		}
+		exists, err = man.functionExists(ctx, owners.Lambda, key, o.name)
+	case ownerCodeBuild:
+		if owners.CodeBuild == nil {
+			return nil
+		}
+		exists, err = man.projectExists(ctx, owners.CodeBuild, key, o.name)
+	case ownerECS:
+		if owners.ECS == nil {
+			return nil
+		}
+		exists, err = man.taskDefinitionExists(ctx, owners.ECS, key, o.name)
+	case ownerAPIGateway:
+		if owners.APIGateway == nil {
+			return nil
+		}
+		exists, err = man.restAPIExists(ctx, owners.APIGateway, key, o.name)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s %q: %w", o.kind, o.name, err)
+	}
+	e.Orphaned = !exists
+	return nil
+}
@@ -0,0 +1,276 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewaytypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	codebuildtypes "github.com/aws/aws-sdk-go-v2/service/codebuild/types"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeOwners struct {
+	exists map[string]bool
+	err    error
+	calls  []string
+}
+
+func (f *fakeOwners) check(kind, region, name string) (bool, error) {
+	f.calls = append(f.calls, kind+":"+region+":"+name)
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.exists[name], nil
+}
+
+func (f *fakeOwners) GetFunction(_ context.Context, params *lambda.GetFunctionInput, optFns ...func(*lambda.Options)) (*lambda.GetFunctionOutput, error) {
+	o := lambda.Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	ok, err := f.check(ownerLambda, o.Region, aws.ToString(params.FunctionName))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &lambdatypes.ResourceNotFoundException{Message: aws.String("Function not found")}
+	}
+	return &lambda.GetFunctionOutput{}, nil
+}
+
+func (f *fakeOwners) BatchGetProjects(_ context.Context, params *codebuild.BatchGetProjectsInput, optFns ...func(*codebuild.Options)) (*codebuild.BatchGetProjectsOutput, error) {
+	o := codebuild.Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	ok, err := f.check(ownerCodeBuild, o.Region, params.Names[0])
+	if err != nil {
+		return nil, err
+	}
+	out := &codebuild.BatchGetProjectsOutput{}
+	if ok {
+		out.Projects = []codebuildtypes.Project{{Name: aws.String(params.Names[0])}}
+	}
+	return out, nil
+}
+
+func (f *fakeOwners) DescribeTaskDefinition(_ context.Context, params *ecs.DescribeTaskDefinitionInput, optFns ...func(*ecs.Options)) (*ecs.DescribeTaskDefinitionOutput, error) {
+	o := ecs.Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	ok, err := f.check(ownerECS, o.Region, aws.ToString(params.TaskDefinition))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &ecstypes.ClientException{Message: aws.String("Unable to describe task definition.")}
+	}
+	return &ecs.DescribeTaskDefinitionOutput{}, nil
+}
+
+func (f *fakeOwners) GetRestApi(_ context.Context, params *apigateway.GetRestApiInput, optFns ...func(*apigateway.Options)) (*apigateway.GetRestApiOutput, error) {
+	o := apigateway.Options{}
+	for _, fn := range optFns {
+		fn(&o)
+	}
+	ok, err := f.check(ownerAPIGateway, o.Region, aws.ToString(params.RestApiId))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &apigatewaytypes.NotFoundException{Message: aws.String("Invalid API identifier specified")}
+	}
+	return &apigateway.GetRestApiOutput{}, nil
+}
+
+func (f *fakeOwners) owners() *Owners {
+	return &Owners{Lambda: f, CodeBuild: f, ECS: f, APIGateway: f}
+}
+
+func TestOwnerOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		group  string
+		want   owner
+		wantOk bool
+	}{
+		{
+			name:   "lambda",
+			group:  "/aws/lambda/my-function",
+			want:   owner{kind: ownerLambda, region: "ap-northeast-1", name: "my-function"},
+			wantOk: true,
+		},
+		{
+			name:   "lambda at edge",
+			group:  "/aws/lambda/us-east-1.my-function",
+			want:   owner{kind: ownerLambda, region: "us-east-1", name: "my-function"},
+			wantOk: true,
+		},
+		{
+			name:   "dotted function",
+			group:  "/aws/lambda/my.function",
+			want:   owner{kind: ownerLambda, region: "ap-northeast-1", name: "my.function"},
+			wantOk: true,
+		},
+		{
+			name:   "dotted function at edge",
+			group:  "/aws/lambda/us-east-1.my.function",
+			want:   owner{kind: ownerLambda, region: "us-east-1", name: "my.function"},
+			wantOk: true,
+		},
+		{
+			name:   "codebuild",
+			group:  "/aws/codebuild/my-project",
+			want:   owner{kind: ownerCodeBuild, region: "ap-northeast-1", name: "my-project"},
+			wantOk: true,
+		},
+		{
+			name:   "ecs",
+			group:  "/ecs/my-family",
+			want:   owner{kind: ownerECS, region: "ap-northeast-1", name: "my-family"},
+			wantOk: true,
+		},
+		{
+			name:   "api gateway",
+			group:  "API-Gateway-Execution-Logs_abc123/prod",
+			want:   owner{kind: ownerAPIGateway, region: "ap-northeast-1", name: "abc123"},
+			wantOk: true,
+		},
+		{
+			name:   "nested ecs",
+			group:  "/ecs/my-cluster/my-service",
+			want:   owner{kind: ownerECS, region: "ap-northeast-1", name: "my-cluster/my-service"},
+			wantOk: false,
+		},
+		{
+			name:   "empty function",
+			group:  "/aws/lambda/",
+			want:   owner{kind: ownerLambda, region: "ap-northeast-1", name: ""},
+			wantOk: false,
+		},
+		{
+			name:   "unknown",
+			group:  "/app/my-service",
+			want:   owner{},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ownerOf(tt.group, "ap-northeast-1")
+			if ok != tt.wantOk {
+				t.Errorf("ownerOf() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(owner{})); diff != "" {
+				t.Errorf("ownerOf() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManager_checkOrphan(t *testing.T) {
+	tests := []struct {
+		name      string
+		group     string
+		owners    *fakeOwners
+		noOwners  bool
+		want      bool
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "existing function",
+			group:     "/aws/lambda/my-function",
+			owners:    &fakeOwners{exists: map[string]bool{"my-function": true}},
+			want:      false,
+			wantCalls: []string{"lambda:us-west-2:my-function"},
+			wantErr:   false,
+		},
+		{
+			name:      "deleted project",
+			group:     "/aws/codebuild/my-project",
+			owners:    &fakeOwners{},
+			want:      true,
+			wantCalls: []string{"codebuild:us-west-2:my-project"},
+			wantErr:   false,
+		},
+		{
+			name:      "deleted edge function",
+			group:     "/aws/lambda/us-east-1.my-function",
+			owners:    &fakeOwners{},
+			want:      true,
+			wantCalls: []string{"lambda:us-east-1:my-function"},
+			wantErr:   false,
+		},
+		{
+			name:      "unknown owner",
+			group:     "/app/my-service",
+			owners:    &fakeOwners{},
+			want:      false,
+			wantCalls: nil,
+			wantErr:   false,
+		},
+		{
+			name:      "no owners",
+			group:     "/aws/lambda/my-function",
+			owners:    &fakeOwners{},
+			noOwners:  true,
+			want:      false,
+			wantCalls: nil,
+			wantErr:   false,
+		},
+		{
+			name:      "deleted task definition",
+			group:     "/ecs/my-family",
+			owners:    &fakeOwners{},
+			want:      true,
+			wantCalls: []string{"ecs:us-west-2:my-family"},
+			wantErr:   false,
+		},
+		{
+			name:      "ecs client exception for another reason",
+			group:     "/ecs/my-family",
+			owners:    &fakeOwners{err: &ecstypes.ClientException{Message: aws.String("The referenced task definition is not accessible.")}},
+			want:      false,
+			wantCalls: []string{"ecs:us-west-2:my-family"},
+			wantErr:   true,
+		},
+		{
+			name:      "api error",
+			group:     "API-Gateway-Execution-Logs_abc123/prod",
+			owners:    &fakeOwners{err: errors.New("api error")},
+			want:      false,
+			wantCalls: []string{"apigateway:us-west-2:abc123"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newMockClient(&mockClient{})
+			if !tt.noOwners {
+				client.SetOwners(tt.owners.owners())
+			}
+			man := &Manager{limiter: newLimiter()}
+			e := &entry{LogGroupName: tt.group, Region: "us-west-2", client: client}
+			err := man.checkOrphan(context.Background(), e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.checkOrphan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if e.Orphaned != tt.want {
+				t.Errorf("Manager.checkOrphan() orphaned = %v, want %v", e.Orphaned, tt.want)
+			}
+			if diff := cmp.Diff(tt.wantCalls, tt.owners.calls); diff != "" {
+				t.Errorf("calls mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
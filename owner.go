@@ -0,0 +1,112 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/apigateway"
+	apigatewaytypes "github.com/aws/aws-sdk-go-v2/service/apigateway/types"
+	"github.com/aws/aws-sdk-go-v2/service/codebuild"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	ecstypes "github.com/aws/aws-sdk-go-v2/service/ecs/types"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	lambdatypes "github.com/aws/aws-sdk-go-v2/service/lambda/types"
+)
+
+var (
+	_ LambdaAPI     = (*lambda.Client)(nil)
+	_ CodeBuildAPI  = (*codebuild.Client)(nil)
+	_ ECSAPI        = (*ecs.Client)(nil)
+	_ APIGatewayAPI = (*apigateway.Client)(nil)
+)
+
+// taskDefinitionNotFoundMessage is the message of the client exception of DescribeTaskDefinition
+// for the family without any active revision.
+const taskDefinitionNotFoundMessage = "Unable to describe task definition"
+
+// NewOwners creates the clients to check the owners of the log groups with the config.
+func NewOwners(cfg aws.Config) *Owners {
+	return &Owners{
+		Lambda:     lambda.NewFromConfig(cfg),
+		CodeBuild:  codebuild.NewFromConfig(cfg),
+		ECS:        ecs.NewFromConfig(cfg),
+		APIGateway: apigateway.NewFromConfig(cfg),
+	}
+}
+
+// functionExists reports whether the Lambda function exists with GetFunction.
+func (man *Manager) functionExists(ctx context.Context, client LambdaAPI, key limitKey, name string) (bool, error) {
+	key.service, key.api = ownerLambda, apiGetFunction
+	err := limitedCall(ctx, man.limiter, key, man.retryer, func(opt func(*lambda.Options)) error {
+		_, err := client.GetFunction(ctx, &lambda.GetFunctionInput{
+			FunctionName: aws.String(name),
+		}, opt)
+		return err
+	}, func(o *lambda.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+	var notFound *lambdatypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// projectExists reports whether the CodeBuild project exists with BatchGetProjects.
+func (man *Manager) projectExists(ctx context.Context, client CodeBuildAPI, key limitKey, name string) (bool, error) {
+	key.service, key.api = ownerCodeBuild, apiBatchGetProjects
+	var exists bool
+	err := limitedCall(ctx, man.limiter, key, man.retryer, func(opt func(*codebuild.Options)) error {
+		out, err := client.BatchGetProjects(ctx, &codebuild.BatchGetProjectsInput{
+			Names: []string{name},
+		}, opt)
+		if err != nil {
+			return err
+		}
+		exists = len(out.Projects) > 0
+		return nil
+	}, func(o *codebuild.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+	return exists, err
+}
+
+// taskDefinitionExists reports whether the ECS task definition family has an active revision with DescribeTaskDefinition.
+// The family without any active revision is rejected as the client exception with the dedicated message,
+// and the client exceptions for any other reason are returned as is.
+func (man *Manager) taskDefinitionExists(ctx context.Context, client ECSAPI, key limitKey, family string) (bool, error) {
+	key.service, key.api = ownerECS, apiDescribeTaskDefinition
+	err := limitedCall(ctx, man.limiter, key, man.retryer, func(opt func(*ecs.Options)) error {
+		_, err := client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
+			TaskDefinition: aws.String(family),
+		}, opt)
+		return err
+	}, func(o *ecs.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+	var clientErr *ecstypes.ClientException
+	if errors.As(err, &clientErr) && strings.Contains(clientErr.ErrorMessage(), taskDefinitionNotFoundMessage) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// restAPIExists reports whether the API Gateway REST API exists with GetRestApi.
+func (man *Manager) restAPIExists(ctx context.Context, client APIGatewayAPI, key limitKey, id string) (bool, error) {
+	key.service, key.api = ownerAPIGateway, apiGetRestApi
+	err := limitedCall(ctx, man.limiter, key, man.retryer, func(opt func(*apigateway.Options)) error {
+		_, err := client.GetRestApi(ctx, &apigateway.GetRestApiInput{
+			RestApiId: aws.String(id),
+		}, opt)
+		return err
+	}, func(o *apigateway.Options) (*string, *aws.Retryer) {
+		return &o.Region, &o.Retryer
+	})
+	var notFound *apigatewaytypes.NotFoundException
+	if errors.As(err, &notFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
@@ -0,0 +1,238 @@
+package llcm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type stubResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func newOwnersForTest(t *testing.T, region string, resps []stubResponse, check func(*http.Request)) *Owners {
+	t.Helper()
+	i := 0
+	cfg := aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient: &http.Client{
+			Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				check(req)
+				resp := resps[min(i, len(resps)-1)]
+				i++
+				header := resp.header
+				if header == nil {
+					header = http.Header{}
+				}
+				return &http.Response{
+					StatusCode: resp.status,
+					Header:     header,
+					Body:       io.NopCloser(strings.NewReader(resp.body)),
+				}, nil
+			}),
+		},
+	}
+	return NewOwners(cfg)
+}
+
+func TestManager_ownerExists(t *testing.T) {
+	type call func(*Manager, *Owners) (bool, error)
+	key := limitKey{accountID: "123456789012", region: "us-east-1"}
+	lambda := func(man *Manager, o *Owners) (bool, error) {
+		return man.functionExists(context.Background(), o.Lambda, key, "my-function")
+	}
+	codeBuild := func(man *Manager, o *Owners) (bool, error) {
+		return man.projectExists(context.Background(), o.CodeBuild, key, "my-project")
+	}
+	ecs := func(man *Manager, o *Owners) (bool, error) {
+		return man.taskDefinitionExists(context.Background(), o.ECS, key, "my-family")
+	}
+	apiGateway := func(man *Manager, o *Owners) (bool, error) {
+		k := key
+		k.region = "cn-north-1"
+		return man.restAPIExists(context.Background(), o.APIGateway, k, "abc123")
+	}
+	throttled := stubResponse{
+		status: http.StatusTooManyRequests,
+		header: http.Header{"X-Amzn-Errortype": {"TooManyRequestsException:http://internal.amazon.com/"}},
+		body:   `{"message":"Rate exceeded"}`,
+	}
+	tests := []struct {
+		name        string
+		call        call
+		resps       []stubResponse
+		wantHost    string
+		wantTgt     string
+		want        bool
+		wantRetries int64
+		wantErr     bool
+	}{
+		{
+			name:     "lambda found",
+			call:     lambda,
+			resps:    []stubResponse{{status: http.StatusOK, body: `{}`}},
+			wantHost: "lambda.us-east-1.amazonaws.com",
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name: "lambda not found",
+			call: lambda,
+			resps: []stubResponse{{
+				status: http.StatusNotFound,
+				header: http.Header{"X-Amzn-Errortype": {"ResourceNotFoundException"}},
+				body:   `{"Message":"Function not found"}`,
+			}},
+			wantHost: "lambda.us-east-1.amazonaws.com",
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:        "lambda throttled once",
+			call:        lambda,
+			resps:       []stubResponse{throttled, {status: http.StatusOK, body: `{}`}},
+			wantHost:    "lambda.us-east-1.amazonaws.com",
+			want:        true,
+			wantRetries: 1,
+			wantErr:     false,
+		},
+		{
+			name:        "lambda throttled",
+			call:        lambda,
+			resps:       []stubResponse{throttled},
+			wantHost:    "lambda.us-east-1.amazonaws.com",
+			want:        false,
+			wantRetries: 2,
+			wantErr:     true,
+		},
+		{
+			name:     "codebuild found",
+			call:     codeBuild,
+			resps:    []stubResponse{{status: http.StatusOK, body: `{"projects":[{"name":"my-project"}],"projectsNotFound":[]}`}},
+			wantHost: "codebuild.us-east-1.amazonaws.com",
+			wantTgt:  "CodeBuild_20161006.BatchGetProjects",
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name:     "codebuild not found",
+			call:     codeBuild,
+			resps:    []stubResponse{{status: http.StatusOK, body: `{"projects":[],"projectsNotFound":["my-project"]}`}},
+			wantHost: "codebuild.us-east-1.amazonaws.com",
+			wantTgt:  "CodeBuild_20161006.BatchGetProjects",
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:        "codebuild server error once",
+			call:        codeBuild,
+			resps:       []stubResponse{{status: http.StatusInternalServerError, body: `{}`}, {status: http.StatusOK, body: `{"projects":[{"name":"my-project"}]}`}},
+			wantHost:    "codebuild.us-east-1.amazonaws.com",
+			wantTgt:     "CodeBuild_20161006.BatchGetProjects",
+			want:        true,
+			wantRetries: 1,
+			wantErr:     false,
+		},
+		{
+			name:     "ecs found",
+			call:     ecs,
+			resps:    []stubResponse{{status: http.StatusOK, body: `{"taskDefinition":{"family":"my-family"}}`}},
+			wantHost: "ecs.us-east-1.amazonaws.com",
+			wantTgt:  "AmazonEC2ContainerServiceV20141113.DescribeTaskDefinition",
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name:     "ecs not found",
+			call:     ecs,
+			resps:    []stubResponse{{status: http.StatusBadRequest, body: `{"__type":"ClientException","message":"Unable to describe task definition."}`}},
+			wantHost: "ecs.us-east-1.amazonaws.com",
+			wantTgt:  "AmazonEC2ContainerServiceV20141113.DescribeTaskDefinition",
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:     "ecs client exception for another reason",
+			call:     ecs,
+			resps:    []stubResponse{{status: http.StatusBadRequest, body: `{"__type":"ClientException","message":"The referenced task definition is not accessible."}`}},
+			wantHost: "ecs.us-east-1.amazonaws.com",
+			wantTgt:  "AmazonEC2ContainerServiceV20141113.DescribeTaskDefinition",
+			want:     false,
+			wantErr:  true,
+		},
+		{
+			name:     "ecs access denied",
+			call:     ecs,
+			resps:    []stubResponse{{status: http.StatusBadRequest, body: `{"__type":"com.amazon.coral.service#AccessDeniedException","message":"denied"}`}},
+			wantHost: "ecs.us-east-1.amazonaws.com",
+			wantTgt:  "AmazonEC2ContainerServiceV20141113.DescribeTaskDefinition",
+			want:     false,
+			wantErr:  true,
+		},
+		{
+			name:     "api gateway found in china",
+			call:     apiGateway,
+			resps:    []stubResponse{{status: http.StatusOK, body: `{"id":"abc123"}`}},
+			wantHost: "apigateway.cn-north-1.amazonaws.com.cn",
+			want:     true,
+			wantErr:  false,
+		},
+		{
+			name: "api gateway not found",
+			call: apiGateway,
+			resps: []stubResponse{{
+				status: http.StatusNotFound,
+				header: http.Header{"X-Amzn-Errortype": {"NotFoundException"}},
+				body:   `{"message":"Invalid API identifier specified"}`,
+			}},
+			wantHost: "apigateway.cn-north-1.amazonaws.com.cn",
+			want:     false,
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owners := newOwnersForTest(t, "ap-northeast-1", tt.resps, func(req *http.Request) {
+				if !strings.HasPrefix(req.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+					t.Errorf("request not signed: %v", req.Header)
+				}
+				if got := req.URL.Host; got != tt.wantHost {
+					t.Errorf("host = %v, want %v", got, tt.wantHost)
+				}
+				if got := req.Header.Get("X-Amz-Target"); got != tt.wantTgt {
+					t.Errorf("target = %v, want %v", got, tt.wantTgt)
+				}
+			})
+			man := &Manager{
+				limiter: newLimiter(),
+				retryer: NewRetryer(3, time.Millisecond),
+			}
+			got, err := tt.call(man, owners)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("got = %v, want %v", got, tt.want)
+			}
+			if got := man.retryer.Stats().Retries; got != tt.wantRetries {
+				t.Errorf("retries = %v, want %v", got, tt.wantRetries)
+			}
+		})
+	}
+}
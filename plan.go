@@ -0,0 +1,146 @@
+package llcm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Plan represents a saved preview that apply executes exactly.
+// It holds the prior state of each log group to detect drift before applying.
+type Plan struct {
+	Version        string       // The version of llcm that created the plan.
+	CreatedAt      time.Time    // The time when the plan was created.
+	Mode           Mode         // The mode in which the plan was created.
+	ForceUnprotect bool         // Whether the protected log groups are unprotected to be deleted.
+	Entries        []*PlanEntry // The planned log group entries.
+}
+
+// PlanEntry represents a log group in the plan with its prior state and the desired state.
+type PlanEntry struct {
+	LogGroupName       string       // The name of the log group.
+	AccountID          string       // The account ID that owns the log group.
+	Region             string       // The region that the log group belongs to.
+	DeletionProtection bool         // Whether the log group was protected to deletion.
+	RetentionInDays    int64        // The retention days of the log group.
+	StoredBytes        int64        // The stored bytes of the log group.
+	DesiredState       DesiredState // The desired state of the log group.
+	Rule               string       // The name of the policy rule that resolved the desired state.
+	ReducibleBytes     int64        // The number of bytes that can be reduced after the action.
+}
+
+// NewPlan creates a new plan from the preview entries, created at the time of the preview.
+// The mode in which the preview was simulated and whether it forced unprotecting are kept for apply.
+func NewPlan(data *PreviewEntryData) *Plan {
+	p := &Plan{
+		Version:        version,
+		CreatedAt:      data.previewedAt,
+		Mode:           data.mode,
+		ForceUnprotect: data.forceUnprotect,
+		Entries:        make([]*PlanEntry, 0, len(data.entries)),
+	}
+	for _, e := range data.entries {
+		p.Entries = append(p.Entries, &PlanEntry{
+			LogGroupName:       e.LogGroupName,
+			AccountID:          e.AccountID,
+			Region:             e.Region,
+			DeletionProtection: e.DeletionProtection,
+			RetentionInDays:    e.RetentionInDays,
+			StoredBytes:        e.StoredBytes,
+			DesiredState:       e.DesiredState,
+			Rule:               e.Rule,
+			ReducibleBytes:     e.ReducibleBytes,
+		})
+	}
+	return p
+}
+
+// LoadPlan loads the plan from the specified JSON file.
+func LoadPlan(path string) (*Plan, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParsePlan(b)
+}
+
+// ParsePlan parses the plan from JSON bytes.
+func ParsePlan(b []byte) (*Plan, error) {
+	p := &Plan{}
+	if err := json.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	seen := make(map[string]struct{}, len(p.Entries))
+	for i, e := range p.Entries {
+		if e == nil {
+			return nil, fmt.Errorf("invalid plan: empty entry at %d", i)
+		}
+		if e.LogGroupName == "" || e.Region == "" {
+			return nil, fmt.Errorf("invalid plan: missing log group name or region at %d", i)
+		}
+		if e.DesiredState == DesiredStateNone {
+			return nil, fmt.Errorf("invalid plan: missing desired state: %q", e.LogGroupName)
+		}
+		key := e.AccountID + "/" + e.Region + "/" + e.LogGroupName
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("invalid plan: duplicate entry: %q", key)
+		}
+		seen[key] = struct{}{}
+	}
+	return p, nil
+}
+
+// UnmarshalJSON parses the JSON representation of the PlanEntry.
+// A missing desired state is left as none, so that it is not taken as delete.
+func (e *PlanEntry) UnmarshalJSON(b []byte) error {
+	type alias PlanEntry
+	a := alias{
+		DesiredState: DesiredStateNone,
+	}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*e = PlanEntry(a)
+	return nil
+}
+
+// Save writes the plan to the specified file as JSON.
+func (p *Plan) Save(path string) error {
+	b, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(path), append(b, '\n'), 0o600)
+}
+
+// drift returns the reason why the current state of the log group differs from the plan.
+// It returns an empty string if the log group has not drifted.
+func (e *PlanEntry) drift(current *entry) string {
+	switch {
+	case current == nil:
+		return "log group no longer exists"
+	case current.RetentionInDays != e.RetentionInDays:
+		return fmt.Sprintf("retention changed: %d -> %d", e.RetentionInDays, current.RetentionInDays)
+	case current.DeletionProtection != e.DeletionProtection:
+		return fmt.Sprintf("protection changed: %t -> %t", e.DeletionProtection, current.DeletionProtection)
+	default:
+		return ""
+	}
+}
+
+// toEntry returns the current entry of the log group, or the entry of the prior state if it no longer exists.
+func (e *PlanEntry) toEntry(current *entry) *entry {
+	if current != nil {
+		return current
+	}
+	return &entry{
+		LogGroupName:       e.LogGroupName,
+		AccountID:          e.AccountID,
+		Region:             e.Region,
+		DeletionProtection: e.DeletionProtection,
+		RetentionInDays:    e.RetentionInDays,
+		StoredBytes:        e.StoredBytes,
+	}
+}
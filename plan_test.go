@@ -0,0 +1,223 @@
+package llcm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPlan(t *testing.T) {
+	want := &Plan{
+		Version:   version,
+		CreatedAt: mustTime("2025-04-01T00:00:00Z"),
+		Entries: []*PlanEntry{
+			{
+				LogGroupName:       "group0",
+				AccountID:          "123456789012",
+				Region:             "ap-northeast-1",
+				DeletionProtection: false,
+				RetentionInDays:    30,
+				StoredBytes:        1024,
+				DesiredState:       DesiredStateZero,
+				Rule:               "",
+				ReducibleBytes:     0,
+			},
+			{
+				LogGroupName:       "group1",
+				AccountID:          "210987654321",
+				Region:             "ap-northeast-2",
+				DeletionProtection: true,
+				RetentionInDays:    30,
+				StoredBytes:        2048,
+				DesiredState:       DesiredStateInfinite,
+				Rule:               "rule1",
+				ReducibleBytes:     100,
+			},
+		},
+	}
+	got := NewPlan(&previewEntryData)
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestPlan_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plan.json")
+	want := NewPlan(&previewEntryData)
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPlan(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Error(diff)
+	}
+	if err := want.Save(filepath.Join(path, "unknown")); err == nil {
+		t.Error("Plan.Save() error = nil, want error")
+	}
+}
+
+func TestLoadPlan(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plan.json")
+	content := `{"Version":"0.1.0","CreatedAt":"2025-04-01T00:00:00Z","Entries":[{"LogGroupName":"group0","AccountID":"123456789012","Region":"ap-northeast-1","RetentionInDays":30,"DesiredState":"1day"}]}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			args: args{
+				path: path,
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			args: args{
+				path: filepath.Join(dir, "unknown.json"),
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadPlan(tt.args.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadPlan() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got == nil {
+				return
+			}
+			if len(got.Entries) != tt.want {
+				t.Errorf("LoadPlan() = %d entries, want %d", len(got.Entries), tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePlan(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			input:   `{"Entries":[{"LogGroupName":"group0","Region":"us-east-1","DesiredState":"delete"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "no entries",
+			input:   `{"Entries":[]}`,
+			wantErr: false,
+		},
+		{
+			name:    "empty entry",
+			input:   `{"Entries":[null]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing region",
+			input:   `{"Entries":[{"LogGroupName":"group0","DesiredState":"delete"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing desired state",
+			input:   `{"Entries":[{"LogGroupName":"group0","Region":"us-east-1"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid desired state",
+			input:   `{"Entries":[{"LogGroupName":"group0","Region":"us-east-1","DesiredState":"unknown"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate entry",
+			input:   `{"Entries":[{"LogGroupName":"group0","Region":"us-east-1","DesiredState":"delete"},{"LogGroupName":"group0","Region":"us-east-1","DesiredState":"1day"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid syntax",
+			input:   `{"Entries":[`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePlan([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlanEntry_drift(t *testing.T) {
+	planned := &PlanEntry{
+		LogGroupName:       "group0",
+		Region:             "us-east-1",
+		DeletionProtection: false,
+		RetentionInDays:    30,
+		DesiredState:       DesiredStateOneDay,
+	}
+	type args struct {
+		current *entry
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "no drift",
+			args: args{
+				current: &entry{RetentionInDays: 30, DeletionProtection: false, StoredBytes: 2048},
+			},
+			want: "",
+		},
+		{
+			name: "not exist",
+			args: args{
+				current: nil,
+			},
+			want: "log group no longer exists",
+		},
+		{
+			name: "retention changed",
+			args: args{
+				current: &entry{RetentionInDays: 7, DeletionProtection: false},
+			},
+			want: "retention changed: 30 -> 7",
+		},
+		{
+			name: "protection changed",
+			args: args{
+				current: &entry{RetentionInDays: 30, DeletionProtection: true},
+			},
+			want: "protection changed: false -> true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := planned.drift(tt.args.current); got != tt.want {
+				t.Errorf("PlanEntry.drift() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package llcm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Policy represents a declarative lifecycle policy with an ordered list of rules.
+// For each log group, the first matching rule wins.
+type Policy struct {
+	Rules []*PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// PolicyRule represents a rule that pairs a filter expression with a desired state.
+// A rule with an empty filter matches all log groups.
+type PolicyRule struct {
+	Name    string `json:"name" yaml:"name"`       // The name of the rule.
+	Filter  string `json:"filter" yaml:"filter"`   // The raw filter string.
+	Desired string `json:"desired" yaml:"desired"` // The desired state string.
+
+	desiredState DesiredState // The parsed desired state.
+	filterExpr   *filterExpr  // The parsed filter expressions.
+}
+
+// LoadPolicy loads the policy from the specified YAML or JSON file.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicy(b)
+}
+
+// ParsePolicy parses the policy from YAML or JSON bytes.
+// Since JSON is a subset of YAML, both formats are accepted.
+func ParsePolicy(b []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := yaml.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if len(p.Rules) == 0 {
+		return nil, fmt.Errorf("invalid policy: no rules")
+	}
+	seen := make(map[string]struct{}, len(p.Rules))
+	for i, rule := range p.Rules {
+		if rule == nil {
+			return nil, fmt.Errorf("invalid policy: empty rule at %d", i)
+		}
+		if rule.Name == "" {
+			rule.Name = "rule" + strconv.Itoa(i+1)
+		}
+		if _, ok := seen[rule.Name]; ok {
+			return nil, fmt.Errorf("invalid policy: duplicate rule name: %q", rule.Name)
+		}
+		seen[rule.Name] = struct{}{}
+		d, err := ParseDesiredState(rule.Desired)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy: rule %q: %w", rule.Name, err)
+		}
+		rule.desiredState = d
+		if rule.Filter != "" {
+			if key, ok := findResultKey(rule.Filter); ok {
+				return nil, fmt.Errorf("invalid policy: rule %q: failed to parse filter: %q is a simulated result", rule.Name, key)
+			}
+			expr, err := parseFilter(rule.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid policy: rule %q: failed to parse filter: %w", rule.Name, err)
+			}
+			rule.filterExpr = expr
+		}
+	}
+	return p, nil
+}
+
+// match returns the first rule that matches the entry.
+// It returns nil if no rule matches.
+func (p *Policy) match(e *entry) (*PolicyRule, error) {
+	for _, rule := range p.Rules {
+		if rule.filterExpr == nil {
+			return rule, nil
+		}
+		ok, err := rule.filterExpr.Eval(e)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if ok {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,242 @@
+package llcm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	yamlPath := filepath.Join(dir, "policy.yaml")
+	yamlContent := `rules:
+  - name: lambda
+    filter: name =~ "^/aws/lambda/"
+    desired: 1month
+  - filter: name =~ "^/ecs"
+    desired: 3months
+  - name: default
+    desired: 1year
+`
+	if err := os.WriteFile(yamlPath, []byte(yamlContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	jsonPath := filepath.Join(dir, "policy.json")
+	jsonContent := `{"rules":[{"name":"lambda","filter":"name =~ \"^/aws/lambda/\"","desired":"1month"},{"desired":"1year"}]}`
+	if err := os.WriteFile(jsonPath, []byte(jsonContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	type args struct {
+		path string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "yaml",
+			args: args{
+				path: yamlPath,
+			},
+			want:    []string{"lambda", "rule2", "default"},
+			wantErr: false,
+		},
+		{
+			name: "json",
+			args: args{
+				path: jsonPath,
+			},
+			want:    []string{"lambda", "rule2"},
+			wantErr: false,
+		},
+		{
+			name: "not found",
+			args: args{
+				path: filepath.Join(dir, "unknown.yaml"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadPolicy(tt.args.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got == nil {
+				return
+			}
+			if len(got.Rules) != len(tt.want) {
+				t.Fatalf("LoadPolicy() = %d rules, want %d", len(got.Rules), len(tt.want))
+			}
+			for i, rule := range got.Rules {
+				if rule.Name != tt.want[i] {
+					t.Errorf("LoadPolicy() rule[%d] = %q, want %q", i, rule.Name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			input:   "rules:\n  - filter: bytes == 0\n    desired: delete\n",
+			wantErr: false,
+		},
+		{
+			name:    "no rules",
+			input:   "rules: []\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty rule",
+			input:   "rules:\n  -\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid desired state",
+			input:   "rules:\n  - desired: unknown\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid filter",
+			input:   "rules:\n  - filter: \"[\"\n    desired: 1day\n",
+			wantErr: true,
+		},
+		{
+			name:    "simulated result in filter",
+			input:   "rules:\n  - filter: action == \"delete\"\n    desired: delete\n",
+			wantErr: true,
+		},
+		{
+			name:    "simulated result key quoted in filter",
+			input:   "rules:\n  - filter: name == \"action\"\n    desired: delete\n",
+			wantErr: false,
+		},
+		{
+			name:    "duplicate rule name",
+			input:   "rules:\n  - name: a\n    desired: 1day\n  - name: a\n    desired: 1year\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid syntax",
+			input:   "rules: [",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParsePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicy_match(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`rules:
+  - name: lambda
+    filter: name =~ "^/aws/lambda/"
+    desired: 1month
+  - name: ecs
+    filter: name =~ "^/ecs"
+    desired: 3months
+  - name: default
+    desired: 1year
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	strict, err := ParsePolicy([]byte("rules:\n  - name: empty\n    filter: bytes == 0\n    desired: delete\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken, err := ParsePolicy([]byte("rules:\n  - name: broken\n    filter: unknown == 0\n    desired: delete\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		policy  *Policy
+		entry   *entry
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "first rule",
+			policy: policy,
+			entry: &entry{
+				LogGroupName: "/aws/lambda/function",
+				Class:        types.LogGroupClassStandard,
+			},
+			want:    "lambda",
+			wantErr: false,
+		},
+		{
+			name:   "second rule",
+			policy: policy,
+			entry: &entry{
+				LogGroupName: "/ecs/service",
+			},
+			want:    "ecs",
+			wantErr: false,
+		},
+		{
+			name:   "fallback rule",
+			policy: policy,
+			entry: &entry{
+				LogGroupName: "/other",
+			},
+			want:    "default",
+			wantErr: false,
+		},
+		{
+			name:   "no match",
+			policy: strict,
+			entry: &entry{
+				LogGroupName: "/other",
+				StoredBytes:  1,
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name:   "eval error",
+			policy: broken,
+			entry: &entry{
+				LogGroupName: "/other",
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.match(tt.entry)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Policy.match() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			name := ""
+			if got != nil {
+				name = got.Name
+			}
+			if name != tt.want {
+				t.Errorf("Policy.match() = %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
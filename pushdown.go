@@ -0,0 +1,238 @@
+package llcm
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+)
+
+var (
+	// nameKeys is the list of filter keys of the log group name.
+	nameKeys = []string{"name", "Name", "LogGroupName"}
+
+	// classKeys is the list of filter keys of the log group class.
+	classKeys = []string{"class", "Class", "LogGroupClass"}
+)
+
+// pushdown represents the conditions of the filter passed to DescribeLogGroups
+// to narrow down the log groups on the server side.
+// Each condition is implied by the filter, so the filter still evaluated on the client side gives the same result.
+type pushdown struct {
+	NamePrefix  string              `json:"namePrefix,omitempty"`  // The prefix that all matching log group names have.
+	NamePattern string              `json:"namePattern,omitempty"` // The substring that all matching log group names contain.
+	Class       types.LogGroupClass `json:"class,omitempty"`       // The class of all matching log groups.
+}
+
+// newPushdown analyzes the raw filter string and returns the conditions that can be pushed down.
+// Only the comparisons joined by && at the top level are taken into account, and nil is returned
+// if there is nothing to push down.
+func newPushdown(raw string) *pushdown {
+	p := &pushdown{}
+	p.analyze(tokenizeFilter(raw))
+	// the name prefix and the name pattern are mutually exclusive
+	if p.NamePrefix != "" {
+		p.NamePattern = ""
+	}
+	if *p == (pushdown{}) {
+		return nil
+	}
+	return p
+}
+
+// analyze collects the conditions from the comparisons joined by && in the tokens.
+func (p *pushdown) analyze(tokens []string) {
+	for len(tokens) > 1 && tokens[0] == "(" && closingParen(tokens) == len(tokens)-1 {
+		tokens = tokens[1 : len(tokens)-1]
+	}
+	var (
+		terms [][]string
+		depth int
+		start int
+	)
+	for i, t := range tokens {
+		switch t {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		case "||":
+			if depth == 0 {
+				return
+			}
+		case "&&":
+			if depth == 0 {
+				terms = append(terms, tokens[start:i])
+				start = i + 1
+			}
+		}
+	}
+	terms = append(terms, tokens[start:])
+	for _, term := range terms {
+		if len(term) > 0 && term[0] == "(" {
+			p.analyze(term)
+			continue
+		}
+		if len(term) != 3 || !isQuote(rune(term[2][0])) {
+			continue
+		}
+		key, op, value := term[0], term[1], term[2][1:len(term[2])-1]
+		switch {
+		case slices.Contains(nameKeys, key) && op == "==":
+			if isNameLiteral(value) && len(value) > len(p.NamePrefix) {
+				p.NamePrefix = value
+			}
+		case slices.Contains(nameKeys, key) && op == "=~":
+			prefix, pattern := analyzeRegex(value)
+			if len(prefix) > len(p.NamePrefix) {
+				p.NamePrefix = prefix
+			}
+			if len(pattern) > len(p.NamePattern) {
+				p.NamePattern = pattern
+			}
+		case slices.Contains(classKeys, key) && op == "==":
+			if class := types.LogGroupClass(value); p.Class == "" && slices.Contains(class.Values(), class) {
+				p.Class = class
+			}
+		}
+	}
+}
+
+// apply sets the conditions to the input of DescribeLogGroups.
+func (p *pushdown) apply(in *cloudwatchlogs.DescribeLogGroupsInput) {
+	if p == nil {
+		return
+	}
+	if p.NamePrefix != "" {
+		in.LogGroupNamePrefix = aws.String(p.NamePrefix)
+	}
+	if p.NamePattern != "" {
+		in.LogGroupNamePattern = aws.String(p.NamePattern)
+	}
+	in.LogGroupClass = p.Class
+}
+
+// analyzeRegex returns the literal that all strings matching the regular expression start with if it is anchored,
+// or the literal that they contain otherwise. The expression with alternations at the top level is not analyzed.
+func analyzeRegex(re string) (prefix, pattern string) {
+	if hasAlternation(re) {
+		return "", ""
+	}
+	anchored := strings.HasPrefix(re, "^")
+	if anchored {
+		re = re[1:]
+	} else {
+		re = strings.TrimPrefix(re, ".*")
+	}
+	var b []rune
+	rs := []rune(re)
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		if r == '\\' && i+1 < len(rs) && strings.ContainsRune(`.-/#`, rs[i+1]) {
+			i++
+			b = append(b, rs[i])
+			continue
+		}
+		if !isNameLiteral(string(r)) || r == '.' {
+			// the last literal is optional with the quantifiers that allow zero
+			if strings.ContainsRune("?*{", r) && len(b) > 0 {
+				b = b[:len(b)-1]
+			}
+			break
+		}
+		b = append(b, r)
+	}
+	if anchored {
+		return string(b), ""
+	}
+	return "", string(b)
+}
+
+// hasAlternation reports whether the regular expression has alternations outside of any groups.
+func hasAlternation(re string) bool {
+	var (
+		depth int
+		class bool
+	)
+	for i := 0; i < len(re); i++ {
+		switch c := re[i]; {
+		case c == '\\':
+			i++
+		case class:
+			class = c != ']'
+		case c == '[':
+			class = true
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == '|' && depth == 0:
+			return true
+		}
+	}
+	return false
+}
+
+// isNameLiteral reports whether the string consists only of the characters allowed
+// in the log group name prefix and pattern of DescribeLogGroups.
+func isNameLiteral(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune("._-/#", r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// tokenizeFilter splits the raw filter string into the quoted strings, the operators, the parentheses
+// and the other identifiers and literals.
+func tokenizeFilter(raw string) []string {
+	var (
+		tokens []string
+		rs     = []rune(raw)
+	)
+	for i := 0; i < len(rs); {
+		j := i + 1
+		switch r := rs[i]; {
+		case isQuote(r):
+			j = scanQuoted(rs, i)
+		case r == '(' || r == ')':
+		case strings.ContainsRune("!=<>&|~*", r):
+			for j < len(rs) && strings.ContainsRune("=<>&|~*", rs[j]) {
+				j++
+			}
+		case isLiteralRune(r):
+			for j < len(rs) && isLiteralRune(rs[j]) {
+				j++
+			}
+		default:
+			i = j
+			continue
+		}
+		tokens = append(tokens, string(rs[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+// closingParen returns the index of the parenthesis that closes the first token, or -1 if not closed.
+func closingParen(tokens []string) int {
+	depth := 0
+	for i, t := range tokens {
+		switch t {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,95 @@
+package llcm
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewPushdown(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want *pushdown
+	}{
+		{
+			name: "anchored regex",
+			raw:  `name =~ "^/aws/lambda/.*"`,
+			want: &pushdown{NamePrefix: "/aws/lambda/"},
+		},
+		{
+			name: "unanchored regex",
+			raw:  `Name =~ 'lambda'`,
+			want: &pushdown{NamePattern: "lambda"},
+		},
+		{
+			name: "regex with escape and quantifier",
+			raw:  `name =~ "^/ecs/app\.v2?-"`,
+			want: &pushdown{NamePrefix: "/ecs/app.v"},
+		},
+		{
+			name: "equal",
+			raw:  `LogGroupName == "/aws/lambda/func" && bytes > 0`,
+			want: &pushdown{NamePrefix: "/aws/lambda/func"},
+		},
+		{
+			name: "prefix over pattern",
+			raw:  `name =~ "func" && name =~ "^/aws/"`,
+			want: &pushdown{NamePrefix: "/aws/"},
+		},
+		{
+			name: "class",
+			raw:  `(class == "INFREQUENT_ACCESS" && (name =~ "^/aws/" && bytes > 0)) && retention == infinite`,
+			want: &pushdown{NamePrefix: "/aws/", Class: types.LogGroupClassInfrequentAccess},
+		},
+		{
+			name: "or",
+			raw:  `name =~ "^/aws/lambda/" || bytes > 0`,
+			want: nil,
+		},
+		{
+			name: "or in parentheses",
+			raw:  `bytes > 0 && (name =~ "^/aws/lambda/" || name =~ "^/ecs/")`,
+			want: nil,
+		},
+		{
+			name: "not",
+			raw:  `!(name =~ "^/aws/lambda/")`,
+			want: nil,
+		},
+		{
+			name: "negative and case insensitive operators",
+			raw:  `name !~ "^/aws/" && name ==* "/ECS/APP" && class != "STANDARD"`,
+			want: nil,
+		},
+		{
+			name: "alternation",
+			raw:  `name =~ "^/aws/(lambda|ecs)/"`,
+			want: &pushdown{NamePrefix: "/aws/"},
+		},
+		{
+			name: "alternation at top level",
+			raw:  `name =~ "^/aws/lambda/|^/ecs/"`,
+			want: nil,
+		},
+		{
+			name: "unsupported class and characters",
+			raw:  `class == "UNKNOWN" && name == "/aws/lambda/func 1"`,
+			want: nil,
+		},
+		{
+			name: "case insensitive flag",
+			raw:  `name =~ "(?i)^/aws/"`,
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPushdown(tt.raw)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("newPushdown() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
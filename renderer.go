@@ -48,6 +48,8 @@ func (ren *Renderer[E, D]) Render() error {
 	switch ren.OutputType {
 	case OutputTypeJSON, OutputTypePrettyJSON:
 		return ren.toJSON()
+	case OutputTypeJSONL:
+		return ren.toJSONL()
 	case OutputTypeText, OutputTypeCompressedText, OutputTypeMarkdown, OutputTypeBacklog:
 		return ren.toTable()
 	case OutputTypeTSV:
@@ -67,6 +69,16 @@ func (ren *Renderer[E, D]) toJSON() error {
 	return b.Encode(ren.Data.Entries())
 }
 
+func (ren *Renderer[E, D]) toJSONL() error {
+	b := json.NewEncoder(ren.w)
+	for _, entry := range ren.Data.Entries() {
+		if err := b.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (ren *Renderer[E, D]) toTable() error {
 	var opt mintab.Option
 	switch ren.OutputType {
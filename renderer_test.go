@@ -162,7 +162,18 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeJSON,
 			},
-			want: `[{"LogGroupName":"group0","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024},{"LogGroupName":"group1","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048}]
+			want: `[{"LogGroupName":"group0","AccountID":"123456789012","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null},{"LogGroupName":"group1","AccountID":"210987654321","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null}]
+`,
+			wantErr: false,
+		},
+		{
+			name: "jsonl",
+			fields: fields{
+				Data:       listEntryData,
+				OutputType: OutputTypeJSONL,
+			},
+			want: `{"LogGroupName":"group0","AccountID":"123456789012","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null}
+{"LogGroupName":"group1","AccountID":"210987654321","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null}
 `,
 			wantErr: false,
 		},
@@ -175,23 +186,35 @@ func TestRenderer_Render1(t *testing.T) {
 			want: `[
   {
     "LogGroupName": "group0",
+    "AccountID": "123456789012",
     "Region": "ap-northeast-1",
     "Class": "STANDARD",
     "CreatedAt": "2025-01-01T00:00:00Z",
     "DeletionProtection": false,
     "ElapsedDays": 90,
     "RetentionInDays": 30,
-    "StoredBytes": 1024
+    "StoredBytes": 1024,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null
   },
   {
     "LogGroupName": "group1",
+    "AccountID": "210987654321",
     "Region": "ap-northeast-2",
     "Class": "INFREQUENT_ACCESS",
     "CreatedAt": "2024-04-01T00:00:00Z",
     "DeletionProtection": true,
     "ElapsedDays": 365,
     "RetentionInDays": 30,
-    "StoredBytes": 2048
+    "StoredBytes": 2048,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null
   }
 ]
 `,
@@ -203,13 +226,13 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeText,
 			},
-			want: `+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
-| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
+			want: `+--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
+| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
 `,
 			wantErr: false,
 		},
@@ -219,12 +242,12 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeCompressedText,
 			},
-			want: `+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
-| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+
+			want: `+--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
+| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+
 `,
 			wantErr: false,
 		},
@@ -234,10 +257,10 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeMarkdown,
 			},
-			want: `| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes |
-|--------|----------------|-------------------|----------------------|--------------------|-------------|-----------------|-------------|
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |
+			want: `| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties |
+|--------|--------------|----------------|-------------------|----------------------|--------------------|-------------|-----------------|-------------|-----|----------|----------------------|-------------------|---------------------|
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | \-  | \-       | \-                   |                 0 | \-                  |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | \-  | \-       | \-                   |                 0 | \-                  |
 `,
 			wantErr: false,
 		},
@@ -247,9 +270,9 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeBacklog,
 			},
-			want: `| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes |h
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |
+			want: `| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties |h
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |
 `,
 			wantErr: false,
 		},
@@ -259,9 +282,9 @@ func TestRenderer_Render1(t *testing.T) {
 				Data:       listEntryData,
 				OutputType: OutputTypeTSV,
 			},
-			want: `Name	Region	Class	CreatedAt	DeletionProtection	ElapsedDays	RetentionInDays	StoredBytes
-group0	ap-northeast-1	STANDARD	2025-01-01T00:00:00Z	false	90	30	1024
-group1	ap-northeast-2	INFREQUENT_ACCESS	2024-04-01T00:00:00Z	true	365	30	2048
+			want: `Name	AccountID	Region	Class	CreatedAt	DeletionProtection	ElapsedDays	RetentionInDays	StoredBytes	Arn	KmsKeyId	DataProtectionStatus	MetricFilterCount	InheritedProperties
+group0	123456789012	ap-northeast-1	STANDARD	2025-01-01T00:00:00Z	false	90	30	1024				0	
+group1	210987654321	ap-northeast-2	INFREQUENT_ACCESS	2024-04-01T00:00:00Z	true	365	30	2048				0	
 `,
 			wantErr: false,
 		},
@@ -329,7 +352,7 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeJSON,
 			},
-			want: `[{"LogGroupName":"group0","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024,"BytesPerDay":0,"DesiredState":"delete","ReductionInDays":0,"ReducibleBytes":0,"RemainingBytes":0},{"LogGroupName":"group1","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048,"BytesPerDay":100,"DesiredState":"infinite","ReductionInDays":100,"ReducibleBytes":100,"RemainingBytes":100}]
+			want: `[{"LogGroupName":"group0","AccountID":"123456789012","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null,"BytesPerDay":0,"DesiredState":"delete","Rule":"","Action":"delete","Reason":"","ReductionInDays":0,"ReducibleBytes":0,"RemainingBytes":0},{"LogGroupName":"group1","AccountID":"210987654321","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null,"BytesPerDay":100,"DesiredState":"infinite","Rule":"rule1","Action":"update","Reason":"","ReductionInDays":100,"ReducibleBytes":100,"RemainingBytes":100}]
 `,
 			wantErr: false,
 		},
@@ -342,6 +365,7 @@ func TestRenderer_Render2(t *testing.T) {
 			want: `[
   {
     "LogGroupName": "group0",
+    "AccountID": "123456789012",
     "Region": "ap-northeast-1",
     "Class": "STANDARD",
     "CreatedAt": "2025-01-01T00:00:00Z",
@@ -349,14 +373,23 @@ func TestRenderer_Render2(t *testing.T) {
     "ElapsedDays": 90,
     "RetentionInDays": 30,
     "StoredBytes": 1024,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null,
     "BytesPerDay": 0,
     "DesiredState": "delete",
+    "Rule": "",
+    "Action": "delete",
+    "Reason": "",
     "ReductionInDays": 0,
     "ReducibleBytes": 0,
     "RemainingBytes": 0
   },
   {
     "LogGroupName": "group1",
+    "AccountID": "210987654321",
     "Region": "ap-northeast-2",
     "Class": "INFREQUENT_ACCESS",
     "CreatedAt": "2024-04-01T00:00:00Z",
@@ -364,8 +397,16 @@ func TestRenderer_Render2(t *testing.T) {
     "ElapsedDays": 365,
     "RetentionInDays": 30,
     "StoredBytes": 2048,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null,
     "BytesPerDay": 100,
     "DesiredState": "infinite",
+    "Rule": "rule1",
+    "Action": "update",
+    "Reason": "",
     "ReductionInDays": 100,
     "ReducibleBytes": 100,
     "RemainingBytes": 100
@@ -380,13 +421,13 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeText,
 			},
-			want: `+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
-| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | BytesPerDay | DesiredState | ReductionInDays | ReducibleBytes | RemainingBytes |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |           0 | delete       |               0 |              0 |              0 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |         100 | infinite     |             100 |            100 |            100 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
+			want: `+--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
+| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties | BytesPerDay | DesiredState | Rule  | Action | Reason | ReductionInDays | ReducibleBytes | RemainingBytes |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |           0 | delete       | -     | delete | -      |               0 |              0 |              0 |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |         100 | infinite     | rule1 | update | -      |             100 |            100 |            100 |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
 `,
 			wantErr: false,
 		},
@@ -396,12 +437,12 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeCompressedText,
 			},
-			want: `+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
-| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | BytesPerDay | DesiredState | ReductionInDays | ReducibleBytes | RemainingBytes |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |           0 | delete       |               0 |              0 |              0 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |         100 | infinite     |             100 |            100 |            100 |
-+--------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-------------+--------------+-----------------+----------------+----------------+
+			want: `+--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
+| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties | BytesPerDay | DesiredState | Rule  | Action | Reason | ReductionInDays | ReducibleBytes | RemainingBytes |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |           0 | delete       | -     | delete | -      |               0 |              0 |              0 |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |         100 | infinite     | rule1 | update | -      |             100 |            100 |            100 |
++--------+--------------+----------------+-------------------+----------------------+--------------------+-------------+-----------------+-------------+-----+----------+----------------------+-------------------+---------------------+-------------+--------------+-------+--------+--------+-----------------+----------------+----------------+
 `,
 			wantErr: false,
 		},
@@ -411,10 +452,10 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeMarkdown,
 			},
-			want: `| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | BytesPerDay | DesiredState | ReductionInDays | ReducibleBytes | RemainingBytes |
-|--------|----------------|-------------------|----------------------|--------------------|-------------|-----------------|-------------|-------------|--------------|-----------------|----------------|----------------|
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |           0 | delete       |               0 |              0 |              0 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |         100 | infinite     |             100 |            100 |            100 |
+			want: `| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties | BytesPerDay | DesiredState | Rule  | Action | Reason | ReductionInDays | ReducibleBytes | RemainingBytes |
+|--------|--------------|----------------|-------------------|----------------------|--------------------|-------------|-----------------|-------------|-----|----------|----------------------|-------------------|---------------------|-------------|--------------|-------|--------|--------|-----------------|----------------|----------------|
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | \-  | \-       | \-                   |                 0 | \-                  |           0 | delete       | \-    | delete | \-     |               0 |              0 |              0 |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | \-  | \-       | \-                   |                 0 | \-                  |         100 | infinite     | rule1 | update | \-     |             100 |            100 |            100 |
 `,
 			wantErr: false,
 		},
@@ -424,9 +465,9 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeBacklog,
 			},
-			want: `| Name   | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | BytesPerDay | DesiredState | ReductionInDays | ReducibleBytes | RemainingBytes |h
-| group0 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 |           0 | delete       |               0 |              0 |              0 |
-| group1 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 |         100 | infinite     |             100 |            100 |            100 |
+			want: `| Name   | AccountID    | Region         | Class             | CreatedAt            | DeletionProtection | ElapsedDays | RetentionInDays | StoredBytes | Arn | KmsKeyId | DataProtectionStatus | MetricFilterCount | InheritedProperties | BytesPerDay | DesiredState | Rule  | Action | Reason | ReductionInDays | ReducibleBytes | RemainingBytes |h
+| group0 | 123456789012 | ap-northeast-1 | STANDARD          | 2025-01-01T00:00:00Z | false              |          90 |              30 |        1024 | -   | -        | -                    |                 0 | -                   |           0 | delete       | -     | delete | -      |               0 |              0 |              0 |
+| group1 | 210987654321 | ap-northeast-2 | INFREQUENT_ACCESS | 2024-04-01T00:00:00Z | true               |         365 |              30 |        2048 | -   | -        | -                    |                 0 | -                   |         100 | infinite     | rule1 | update | -      |             100 |            100 |            100 |
 `,
 			wantErr: false,
 		},
@@ -436,9 +477,9 @@ func TestRenderer_Render2(t *testing.T) {
 				Data:       previewEntryData,
 				OutputType: OutputTypeTSV,
 			},
-			want: `Name	Region	Class	CreatedAt	DeletionProtection	ElapsedDays	RetentionInDays	StoredBytes	BytesPerDay	DesiredState	ReductionInDays	ReducibleBytes	RemainingBytes
-group0	ap-northeast-1	STANDARD	2025-01-01T00:00:00Z	false	90	30	1024	0	delete	0	0	0
-group1	ap-northeast-2	INFREQUENT_ACCESS	2024-04-01T00:00:00Z	true	365	30	2048	100	infinite	100	100	100
+			want: `Name	AccountID	Region	Class	CreatedAt	DeletionProtection	ElapsedDays	RetentionInDays	StoredBytes	Arn	KmsKeyId	DataProtectionStatus	MetricFilterCount	InheritedProperties	BytesPerDay	DesiredState	Rule	Action	Reason	ReductionInDays	ReducibleBytes	RemainingBytes
+group0	123456789012	ap-northeast-1	STANDARD	2025-01-01T00:00:00Z	false	90	30	1024				0		0	delete		delete		0	0	0
+group1	210987654321	ap-northeast-2	INFREQUENT_ACCESS	2024-04-01T00:00:00Z	true	365	30	2048				0		100	infinite	rule1	update		100	100	100
 `,
 			wantErr: false,
 		},
@@ -488,6 +529,204 @@ group1	ap-northeast-2	INFREQUENT_ACCESS	2024-04-01T00:00:00Z	true	365	30	2048	10
 	}
 }
 
+func TestRenderer_Render3(t *testing.T) {
+	type fields struct {
+		Data       ApplyEntryData
+		OutputType OutputType
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "json",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeJSON,
+			},
+			want: `[{"LogGroupName":"group0","AccountID":"123456789012","Region":"ap-northeast-1","Class":"STANDARD","CreatedAt":"2025-01-01T00:00:00Z","DeletionProtection":false,"ElapsedDays":90,"RetentionInDays":30,"StoredBytes":1024,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null,"DesiredState":"1day","Action":"update","RetentionBefore":30,"RetentionAfter":1,"ProtectionBefore":false,"ProtectionAfter":false,"Unprotected":false,"Success":true,"Error":"","Duration":120000000},{"LogGroupName":"group1","AccountID":"210987654321","Region":"ap-northeast-2","Class":"INFREQUENT_ACCESS","CreatedAt":"2024-04-01T00:00:00Z","DeletionProtection":true,"ElapsedDays":365,"RetentionInDays":30,"StoredBytes":2048,"Arn":"","KmsKeyId":"","DataProtectionStatus":"","MetricFilterCount":0,"InheritedProperties":null,"DesiredState":"delete","Action":"delete","RetentionBefore":30,"RetentionAfter":30,"ProtectionBefore":true,"ProtectionAfter":true,"Unprotected":false,"Success":false,"Error":"api error","Duration":80000000}]
+`,
+			wantErr: false,
+		},
+		{
+			name: "prettyjson",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypePrettyJSON,
+			},
+			want: `[
+  {
+    "LogGroupName": "group0",
+    "AccountID": "123456789012",
+    "Region": "ap-northeast-1",
+    "Class": "STANDARD",
+    "CreatedAt": "2025-01-01T00:00:00Z",
+    "DeletionProtection": false,
+    "ElapsedDays": 90,
+    "RetentionInDays": 30,
+    "StoredBytes": 1024,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null,
+    "DesiredState": "1day",
+    "Action": "update",
+    "RetentionBefore": 30,
+    "RetentionAfter": 1,
+    "ProtectionBefore": false,
+    "ProtectionAfter": false,
+    "Unprotected": false,
+    "Success": true,
+    "Error": "",
+    "Duration": 120000000
+  },
+  {
+    "LogGroupName": "group1",
+    "AccountID": "210987654321",
+    "Region": "ap-northeast-2",
+    "Class": "INFREQUENT_ACCESS",
+    "CreatedAt": "2024-04-01T00:00:00Z",
+    "DeletionProtection": true,
+    "ElapsedDays": 365,
+    "RetentionInDays": 30,
+    "StoredBytes": 2048,
+    "Arn": "",
+    "KmsKeyId": "",
+    "DataProtectionStatus": "",
+    "MetricFilterCount": 0,
+    "InheritedProperties": null,
+    "DesiredState": "delete",
+    "Action": "delete",
+    "RetentionBefore": 30,
+    "RetentionAfter": 30,
+    "ProtectionBefore": true,
+    "ProtectionAfter": true,
+    "Unprotected": false,
+    "Success": false,
+    "Error": "api error",
+    "Duration": 80000000
+  }
+]
+`,
+			wantErr: false,
+		},
+		{
+			name: "text",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeText,
+			},
+			want: `+--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+| Name   | AccountID    | Region         | StoredBytes | DesiredState | Action | RetentionBefore | RetentionAfter | ProtectionBefore | ProtectionAfter | Unprotected | Success | Error     | Duration |
++--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+| group0 | 123456789012 | ap-northeast-1 |        1024 | 1day         | update |              30 |              1 | false            | false           | false       | true    | -         | 120ms    |
++--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+| group1 | 210987654321 | ap-northeast-2 |        2048 | delete       | delete |              30 |             30 | true             | true            | false       | false   | api error | 80ms     |
++--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+`,
+			wantErr: false,
+		},
+		{
+			name: "compressed",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeCompressedText,
+			},
+			want: `+--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+| Name   | AccountID    | Region         | StoredBytes | DesiredState | Action | RetentionBefore | RetentionAfter | ProtectionBefore | ProtectionAfter | Unprotected | Success | Error     | Duration |
++--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+| group0 | 123456789012 | ap-northeast-1 |        1024 | 1day         | update |              30 |              1 | false            | false           | false       | true    | -         | 120ms    |
+| group1 | 210987654321 | ap-northeast-2 |        2048 | delete       | delete |              30 |             30 | true             | true            | false       | false   | api error | 80ms     |
++--------+--------------+----------------+-------------+--------------+--------+-----------------+----------------+------------------+-----------------+-------------+---------+-----------+----------+
+`,
+			wantErr: false,
+		},
+		{
+			name: "markdown",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeMarkdown,
+			},
+			want: `| Name   | AccountID    | Region         | StoredBytes | DesiredState | Action | RetentionBefore | RetentionAfter | ProtectionBefore | ProtectionAfter | Unprotected | Success | Error     | Duration |
+|--------|--------------|----------------|-------------|--------------|--------|-----------------|----------------|------------------|-----------------|-------------|---------|-----------|----------|
+| group0 | 123456789012 | ap-northeast-1 |        1024 | 1day         | update |              30 |              1 | false            | false           | false       | true    | \-        | 120ms    |
+| group1 | 210987654321 | ap-northeast-2 |        2048 | delete       | delete |              30 |             30 | true             | true            | false       | false   | api error | 80ms     |
+`,
+			wantErr: false,
+		},
+		{
+			name: "backlog",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeBacklog,
+			},
+			want: `| Name   | AccountID    | Region         | StoredBytes | DesiredState | Action | RetentionBefore | RetentionAfter | ProtectionBefore | ProtectionAfter | Unprotected | Success | Error     | Duration |h
+| group0 | 123456789012 | ap-northeast-1 |        1024 | 1day         | update |              30 |              1 | false            | false           | false       | true    | -         | 120ms    |
+| group1 | 210987654321 | ap-northeast-2 |        2048 | delete       | delete |              30 |             30 | true             | true            | false       | false   | api error | 80ms     |
+`,
+			wantErr: false,
+		},
+		{
+			name: "tsv",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeTSV,
+			},
+			want: `Name	AccountID	Region	StoredBytes	DesiredState	Action	RetentionBefore	RetentionAfter	ProtectionBefore	ProtectionAfter	Unprotected	Success	Error	Duration
+group0	123456789012	ap-northeast-1	1024	1day	update	30	1	false	false	false	true		120ms
+group1	210987654321	ap-northeast-2	2048	delete	delete	30	30	true	true	false	false	api error	80ms
+`,
+			wantErr: false,
+		},
+		{
+			name: "chart",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeChart,
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "unknown output type",
+			fields: fields{
+				Data:       applyEntryData,
+				OutputType: OutputTypeNone,
+			},
+			want:    "",
+			wantErr: false,
+		},
+		{
+			name: "table error",
+			fields: fields{
+				Data:       errApplyEntryData,
+				OutputType: OutputTypeText,
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &bytes.Buffer{}
+			ren := &Renderer[*ApplyEntry, *ApplyEntryData]{
+				Data:       &tt.fields.Data,
+				OutputType: tt.fields.OutputType,
+				w:          w,
+			}
+			if err := ren.Render(); (err != nil) != tt.wantErr {
+				t.Errorf("Renderer.Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := w.String(); got != tt.want {
+				t.Errorf("Renderer.Render() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestRenderer_toChart1(t *testing.T) {
 	type fields struct {
 		Data       *ListEntryData
@@ -0,0 +1,164 @@
+package llcm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retentions is the list of retention values allowed by CloudWatch Logs in ascending order.
+var retentions = []DesiredState{
+	DesiredStateOneDay,
+	DesiredStateThreeDays,
+	DesiredStateFiveDays,
+	DesiredStateOneWeek,
+	DesiredStateTwoWeeks,
+	DesiredStateOneMonth,
+	DesiredStateTwoMonths,
+	DesiredStateThreeMonths,
+	DesiredStateFourMonths,
+	DesiredStateFiveMonths,
+	DesiredStateSixMonths,
+	DesiredStateOneYear,
+	DesiredStateThirteenMonths,
+	DesiredStateEighteenMonths,
+	DesiredStateTwoYears,
+	DesiredStateThreeYears,
+	DesiredStateFiveYears,
+	DesiredStateSixYears,
+	DesiredStateSevenYears,
+	DesiredStateEightYears,
+	DesiredStateNineYears,
+	DesiredStateTenYears,
+}
+
+// isoDurationPattern is the pattern of the ISO-8601 duration, e.g. P1Y, P13M, P2W, P90D and PT2160H.
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// dayCountPattern is the pattern of the day count with the unit, e.g. 90d, 1day and 90days.
+var dayCountPattern = regexp.MustCompile(`^(\d+)(?:d|days?)$`)
+
+// parseRetention parses a raw day count, a day count with the unit, an ISO-8601 duration or a Go duration into a DesiredState.
+// The retention that is not allowed is snapped in the direction of the round, or rejected without it.
+func parseRetention(s string, round Round) (DesiredState, error) {
+	days, err := parseDays(s)
+	if err != nil {
+		return DesiredStateNone, fmt.Errorf("unsupported desired state: %q", s)
+	}
+	if days <= 0 {
+		return DesiredStateNone, fmt.Errorf("unsupported desired state: %q: retention must be positive", s)
+	}
+	d, err := snapRetention(days, round)
+	if err != nil {
+		return DesiredStateNone, fmt.Errorf("unsupported desired state: %q: %w", s, err)
+	}
+	return d, nil
+}
+
+// parseDays parses a raw day count, a day count with the unit, an ISO-8601 duration or a Go duration into days.
+func parseDays(s string) (float64, error) {
+	if n, err := strconv.ParseInt(s, 10, 32); err == nil {
+		return float64(n), nil
+	}
+	if m := dayCountPattern.FindStringSubmatch(s); m != nil {
+		n, err := strconv.ParseInt(m[1], 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		return float64(n), nil
+	}
+	if strings.HasPrefix(s, "P") {
+		return parseISODuration(s)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return d.Hours() / 24, nil
+}
+
+// parseISODuration parses an ISO-8601 duration into days.
+// The duration of only years or only months is taken as the named desired state if any, e.g. P13M as 400 days.
+// Otherwise, a year is counted as 365 days and a month as 30 days.
+func parseISODuration(s string) (float64, error) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || s == "P" || strings.HasSuffix(s, "T") {
+		return 0, fmt.Errorf("invalid duration: %q", s)
+	}
+	var v [7]float64
+	for i, x := range m[1:] {
+		if x == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(x, 64)
+		if err != nil {
+			return 0, err
+		}
+		v[i] = n
+	}
+	years, months := v[0], v[1]
+	if rest := v[2] + v[3] + v[4] + v[5] + v[6]; rest == 0 && (years == 0) != (months == 0) {
+		if d, ok := namedRetention(years, months); ok {
+			return float64(d), nil
+		}
+	}
+	return years*365 + months*30 + v[2]*7 + v[3] + v[4]/24 + v[5]/(24*60) + v[6]/(24*60*60), nil
+}
+
+// namedRetention returns the named desired state for the years or months, e.g. 2years or 13months.
+func namedRetention(years, months float64) (DesiredState, bool) {
+	n, unit := years, "year"
+	if months != 0 {
+		n, unit = months, "month"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	for _, d := range retentions {
+		if d.String() == strconv.FormatFloat(n, 'f', -1, 64)+unit {
+			return d, true
+		}
+	}
+	return DesiredStateNone, false
+}
+
+// snapRetention returns the allowed retention for the days in the direction of the round.
+func snapRetention(days float64, round Round) (DesiredState, error) {
+	i, found := slices.BinarySearchFunc(retentions, days, func(d DesiredState, days float64) int {
+		switch {
+		case float64(d) < days:
+			return -1
+		case float64(d) > days:
+			return 1
+		default:
+			return 0
+		}
+	})
+	if found {
+		return retentions[i], nil
+	}
+	s := strconv.FormatFloat(days, 'f', -1, 64)
+	switch round {
+	case RoundUp:
+		if i == len(retentions) {
+			return DesiredStateNone, fmt.Errorf("%s days exceeds the longest retention of %d days", s, retentions[len(retentions)-1])
+		}
+		return retentions[i], nil
+	case RoundDown:
+		if i == 0 {
+			return DesiredStateNone, fmt.Errorf("%s days is shorter than the shortest retention of %d days", s, retentions[0])
+		}
+		return retentions[i-1], nil
+	}
+	switch i {
+	case 0:
+		return DesiredStateNone, fmt.Errorf("%s days is not an allowed retention, the shortest is %d days", s, retentions[0])
+	case len(retentions):
+		return DesiredStateNone, fmt.Errorf("%s days is not an allowed retention, the longest is %d days", s, retentions[len(retentions)-1])
+	default:
+		return DesiredStateNone, fmt.Errorf("%s days is not an allowed retention, the nearest are %d and %d days", s, retentions[i-1], retentions[i])
+	}
+}
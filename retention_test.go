@@ -0,0 +1,84 @@
+package llcm
+
+import (
+	"testing"
+)
+
+func TestSnapRetention(t *testing.T) {
+	type args struct {
+		days  float64
+		round Round
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    DesiredState
+		wantErr bool
+	}{
+		{
+			name: "allowed",
+			args: args{
+				days:  365,
+				round: RoundNone,
+			},
+			want:    DesiredStateOneYear,
+			wantErr: false,
+		},
+		{
+			name: "between allowed",
+			args: args{
+				days:  100,
+				round: RoundNone,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "shorter than the shortest",
+			args: args{
+				days:  0.5,
+				round: RoundNone,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "longer than the longest",
+			args: args{
+				days:  4000,
+				round: RoundNone,
+			},
+			want:    DesiredStateNone,
+			wantErr: true,
+		},
+		{
+			name: "round up",
+			args: args{
+				days:  1.5,
+				round: RoundUp,
+			},
+			want:    DesiredStateThreeDays,
+			wantErr: false,
+		},
+		{
+			name: "round down",
+			args: args{
+				days:  4000,
+				round: RoundDown,
+			},
+			want:    DesiredStateTenYears,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := snapRetention(tt.args.days, tt.args.round)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("snapRetention() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("snapRetention() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
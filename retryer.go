@@ -2,81 +2,274 @@ package llcm
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/rand"
-	"strings"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/smithy-go"
 )
 
 var _ aws.RetryerV2 = (*Retryer)(nil)
 
 var (
 	// MaxRetryAttempts is the maximum number of retry attempts.
+	//
+	// Deprecated: Use WithRetryer with NewRetryer instead. It no longer affects the behavior.
 	MaxRetryAttempts = 10
 
-	// DelayTimeSec is the sleep time in seconds for retry.
+	// DelayTimeSec is the maximum sleep time in seconds for retry.
+	//
+	// Deprecated: Use WithRetryer with NewRetryer instead. It no longer affects the behavior.
 	DelayTimeSec = 3
 )
 
+const (
+	// defaultMaxRetryAttempts is the default maximum number of retry attempts.
+	defaultMaxRetryAttempts = 10
+
+	// defaultMaxRetryDelay is the default maximum sleep time for retry.
+	defaultMaxRetryDelay = 3 * time.Second
+
+	// baseRetryDelay is the sleep time for the first retry before the jitter.
+	baseRetryDelay = 200 * time.Millisecond
+
+	// retryQuotaCapacity is the capacity of the retry quota.
+	retryQuotaCapacity = 500
+
+	// retryCost is the cost of the retry quota for a retry.
+	retryCost = 5
+
+	// timeoutRetryCost is the cost of the retry quota for a retry on a timeout.
+	timeoutRetryCost = 10
+
+	// noRetryIncrement is the amount of the retry quota refilled for a successful attempt without retry.
+	noRetryIncrement = 1
+)
+
 var (
-	retryer       = NewRetryer(retryableFunc, DelayTimeSec)
-	retryableFunc = func(err error) bool {
-		return strings.Contains(err.Error(), "api error ThrottlingException")
+	// throttlingErrorCodes is the set of the error codes for throttling.
+	throttlingErrorCodes = map[string]struct{}{
+		"ThrottlingException":       {},
+		"Throttling":                {},
+		"ThrottledException":        {},
+		"RequestThrottled":          {},
+		"RequestThrottledException": {},
+		"RequestLimitExceeded":      {},
+		"TooManyRequestsException":  {},
+	}
+
+	// unavailableErrorCodes is the set of the error codes for the service unavailable.
+	unavailableErrorCodes = map[string]struct{}{
+		"ServiceUnavailable":          {},
+		"ServiceUnavailableException": {},
+		"InternalFailure":             {},
+		"InternalServerError":         {},
+		"RequestTimeout":              {},
+		"RequestTimeoutException":     {},
 	}
 )
 
-// Retryer represents a retryer for avoiding api rate limit exceeded.
+// RetryStats represents the number of retries and throttled attempts.
+type RetryStats struct {
+	Retries   int64 `json:"retries"`   // The number of retries.
+	Throttles int64 `json:"throttles"` // The number of attempts throttled.
+}
+
+// Retryer represents a retryer with capped exponential backoff and jitter for the throttling,
+// the service unavailable, the server errors and the transient network errors.
+// The retries are limited by the retry quota shared by all calls, which is consumed by each retry
+// and refilled by the successful attempts.
 type Retryer struct {
-	isErrorRetryableFunc func(error) bool
-	delayTimeSec         int
+	maxAttempts int
+	maxDelay    time.Duration
+	quota       *retryQuota
+	retries     atomic.Int64
+	throttles   atomic.Int64
 }
 
-// NewRetryer creates a new retryer.
-func NewRetryer(isErrorRetryableFunc func(error) bool, delayTimeSec int) *Retryer {
+// NewRetryer creates a new retryer with the maximum number of attempts and the maximum sleep time for retry.
+func NewRetryer(maxAttempts int, maxDelay time.Duration) *Retryer {
 	return &Retryer{
-		isErrorRetryableFunc: isErrorRetryableFunc,
-		delayTimeSec:         delayTimeSec,
+		maxAttempts: maxAttempts,
+		maxDelay:    maxDelay,
+		quota:       newRetryQuota(retryQuotaCapacity),
 	}
 }
 
 // IsErrorRetryable checks if the error is retryable.
 func (r *Retryer) IsErrorRetryable(err error) bool {
-	return r.isErrorRetryableFunc(err)
+	return isRetryableError(err)
 }
 
 // MaxAttempts returns the maximum number of retry attempts.
 func (r *Retryer) MaxAttempts() int {
-	return MaxRetryAttempts
+	return r.maxAttempts
 }
 
 // RetryDelay returns the delay time for retry.
-func (r *Retryer) RetryDelay(int, error) (time.Duration, error) {
-	if r.delayTimeSec <= 0 {
-		return 0, fmt.Errorf("invalid delay time: %d", r.delayTimeSec)
-	}
-	var (
-		rng  = rand.New(rand.NewSource(nowFunc().UnixNano())) // #nosec G404
-		wait = 1
-	)
-	if r.delayTimeSec > 1 {
-		wait += rng.Intn(r.delayTimeSec)
+// It grows exponentially with the attempt up to the maximum, and the full jitter is applied.
+// The SDK sleeps for the delay within the context, so the retry stops as soon as the context is done.
+func (r *Retryer) RetryDelay(attempt int, _ error) (time.Duration, error) {
+	if r.maxDelay <= 0 {
+		return 0, fmt.Errorf("invalid delay time: %s", r.maxDelay)
 	}
-	return time.Duration(wait) * time.Second, nil
+	return rand.N(backoff(attempt, baseRetryDelay, r.maxDelay) + 1), nil // #nosec G404
 }
 
-// GetRetryToken returns the retry token.
-func (r *Retryer) GetRetryToken(context.Context, error) (func(error) error, error) {
-	return func(error) error { return nil }, nil
+// GetRetryToken consumes the retry quota for the error and returns the function to refund it on success.
+func (r *Retryer) GetRetryToken(_ context.Context, err error) (func(error) error, error) {
+	cost := retryCost
+	if isTimeoutError(err) {
+		cost = timeoutRetryCost
+	}
+	if !r.quota.take(cost) {
+		return nil, fmt.Errorf("retry quota exceeded: %d available, %d required", r.quota.available(), cost)
+	}
+	r.retries.Add(1)
+	return func(err error) error {
+		if err == nil {
+			r.quota.put(cost)
+		}
+		return nil
+	}, nil
 }
 
 // GetInitialToken returns the initial token.
 func (r *Retryer) GetInitialToken() func(error) error {
-	return func(error) error { return nil }
+	return r.release
 }
 
-// GetAttemptToken returns the attempt token.
+// GetAttemptToken returns the attempt token that counts the throttled attempt
+// and refills the retry quota on success.
 func (r *Retryer) GetAttemptToken(context.Context) (func(error) error, error) {
-	return func(error) error { return nil }, nil
+	return r.release, nil
+}
+
+// Stats returns the number of retries and throttled attempts so far.
+func (r *Retryer) Stats() RetryStats {
+	return RetryStats{
+		Retries:   r.retries.Load(),
+		Throttles: r.throttles.Load(),
+	}
+}
+
+// release records the result of the attempt.
+func (r *Retryer) release(err error) error {
+	switch {
+	case err == nil:
+		r.quota.put(noRetryIncrement)
+	case isThrottlingError(err):
+		r.throttles.Add(1)
+	}
+	return nil
+}
+
+// backoff returns the exponential delay for the attempt starting from 1, capped at the maximum.
+func backoff(attempt int, base, maximum time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempt && d < maximum; i++ {
+		d *= 2
+	}
+	return min(d, maximum)
+}
+
+// retryQuota represents the tokens consumed by retries.
+type retryQuota struct {
+	mu       sync.Mutex
+	capacity int
+	tokens   int
+}
+
+// newRetryQuota creates a new retry quota with full tokens.
+func newRetryQuota(capacity int) *retryQuota {
+	return &retryQuota{
+		capacity: capacity,
+		tokens:   capacity,
+	}
+}
+
+// take consumes the tokens if available.
+func (q *retryQuota) take(n int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.tokens < n {
+		return false
+	}
+	q.tokens -= n
+	return true
+}
+
+// put refills the tokens up to the capacity.
+func (q *retryQuota) put(n int) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.tokens = min(q.tokens+n, q.capacity)
+}
+
+// available returns the number of the tokens available.
+func (q *retryQuota) available() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.tokens
+}
+
+// isRetryableError reports whether the error is the throttling, the service unavailable,
+// a server error or a transient network error. The canceled context is never retried.
+func isRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return isThrottlingError(err) || isUnavailableError(err) || isServerError(err) || isTransientError(err)
+}
+
+// isThrottlingError reports whether the error is the throttling of the API.
+func isThrottlingError(err error) bool {
+	var ae smithy.APIError
+	if !errors.As(err, &ae) {
+		return false
+	}
+	_, ok := throttlingErrorCodes[ae.ErrorCode()]
+	return ok
+}
+
+// isUnavailableError reports whether the error is the service unavailable of the API.
+func isUnavailableError(err error) bool {
+	var ae smithy.APIError
+	if !errors.As(err, &ae) {
+		return false
+	}
+	_, ok := unavailableErrorCodes[ae.ErrorCode()]
+	return ok
+}
+
+// isServerError reports whether the error is the response with the 5xx status code.
+func isServerError(err error) bool {
+	var re interface{ HTTPStatusCode() int }
+	return errors.As(err, &re) && re.HTTPStatusCode() >= 500 && re.HTTPStatusCode() <= 599
+}
+
+// isTransientError reports whether the error is a transient network error,
+// e.g. the timeout, the connection reset or refused, and the unexpected end of the response.
+func isTransientError(err error) bool {
+	if isTimeoutError(err) {
+		return true
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var oe *net.OpError
+	return errors.As(err, &oe) && oe.Op == "dial"
+}
+
+// isTimeoutError reports whether the error is a network timeout.
+func isTimeoutError(err error) bool {
+	var ne net.Error
+	return errors.As(err, &ne) && ne.Timeout()
 }
@@ -0,0 +1,221 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "throttling",
+			err:  &smithy.GenericAPIError{Code: "ThrottlingException"},
+			want: true,
+		},
+		{
+			name: "service unavailable",
+			err:  fmt.Errorf("operation error: %w", &smithy.GenericAPIError{Code: "ServiceUnavailableException"}),
+			want: true,
+		},
+		{
+			name: "server error",
+			err:  &smithyhttp.ResponseError{Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 502}}, Err: errors.New("bad gateway")},
+			want: true,
+		},
+		{
+			name: "client error",
+			err:  &smithyhttp.ResponseError{Response: &smithyhttp.Response{Response: &http.Response{StatusCode: 400}}, Err: errors.New("bad request")},
+			want: false,
+		},
+		{
+			name: "resource not found",
+			err:  &smithy.GenericAPIError{Code: "ResourceNotFoundException"},
+			want: false,
+		},
+		{
+			name: "network timeout",
+			err:  &net.DNSError{Err: "i/o timeout", IsTimeout: true},
+			want: true,
+		},
+		{
+			name: "connection reset",
+			err:  &net.OpError{Op: "read", Err: syscall.ECONNRESET},
+			want: true,
+		},
+		{
+			name: "connection refused",
+			err:  &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+			want: true,
+		},
+		{
+			name: "unexpected eof",
+			err:  fmt.Errorf("failed to read response: %w", io.ErrUnexpectedEOF),
+			want: true,
+		},
+		{
+			name: "canceled",
+			err:  context.Canceled,
+			want: false,
+		},
+		{
+			name: "deadline exceeded",
+			err:  context.DeadlineExceeded,
+			want: false,
+		},
+		{
+			name: "other",
+			err:  errors.New("api error ThrottlingException"),
+			want: false,
+		},
+		{
+			name: "nil",
+			err:  nil,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRetryer(defaultMaxRetryAttempts, time.Second).IsErrorRetryable(tt.err); got != tt.want {
+				t.Errorf("Retryer.IsErrorRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt int
+		want    time.Duration
+	}{
+		{
+			name:    "first",
+			attempt: 1,
+			want:    200 * time.Millisecond,
+		},
+		{
+			name:    "third",
+			attempt: 3,
+			want:    800 * time.Millisecond,
+		},
+		{
+			name:    "capped",
+			attempt: 5,
+			want:    time.Second,
+		},
+		{
+			name:    "large attempt",
+			attempt: 1000,
+			want:    time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoff(tt.attempt, baseRetryDelay, time.Second); got != tt.want {
+				t.Errorf("backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryer_RetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		wantErr  bool
+	}{
+		{
+			name:     "basic",
+			maxDelay: 3 * time.Second,
+			wantErr:  false,
+		},
+		{
+			name:     "invalid delay time",
+			maxDelay: 0,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRetryer(defaultMaxRetryAttempts, tt.maxDelay)
+			for attempt := 1; attempt <= defaultMaxRetryAttempts; attempt++ {
+				got, err := r.RetryDelay(attempt, nil)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Retryer.RetryDelay() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					return
+				}
+				if limit := backoff(attempt, baseRetryDelay, tt.maxDelay); got < 0 || got > limit {
+					t.Errorf("Retryer.RetryDelay() = %v, want between 0 and %v", got, limit)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryer_GetRetryToken(t *testing.T) {
+	r := NewRetryer(defaultMaxRetryAttempts, time.Second)
+	throttled := &smithy.GenericAPIError{Code: "ThrottlingException"}
+	var release func(error) error
+	for range retryQuotaCapacity / retryCost {
+		var err error
+		if release, err = r.GetRetryToken(context.Background(), throttled); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.GetRetryToken(context.Background(), throttled); err == nil {
+		t.Errorf("Retryer.GetRetryToken() error = nil, want retry quota exceeded")
+	}
+	if err := release(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.GetRetryToken(context.Background(), throttled); err != nil {
+		t.Errorf("Retryer.GetRetryToken() error = %v, want nil after refund", err)
+	}
+	if _, err := r.GetRetryToken(context.Background(), &net.DNSError{IsTimeout: true}); err == nil {
+		t.Errorf("Retryer.GetRetryToken() error = nil, want retry quota exceeded")
+	}
+	if got := r.Stats().Retries; got != retryQuotaCapacity/retryCost+1 {
+		t.Errorf("Retryer.Stats().Retries = %v, want %v", got, retryQuotaCapacity/retryCost+1)
+	}
+}
+
+func TestRetryer_Stats(t *testing.T) {
+	r := NewRetryer(defaultMaxRetryAttempts, time.Second)
+	for _, err := range []error{
+		&smithy.GenericAPIError{Code: "ThrottlingException"},
+		&smithy.GenericAPIError{Code: "TooManyRequestsException"},
+		&smithy.GenericAPIError{Code: "ServiceUnavailableException"},
+		nil,
+	} {
+		release, err2 := r.GetAttemptToken(context.Background())
+		if err2 != nil {
+			t.Fatal(err2)
+		}
+		if err := release(err); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := r.GetRetryToken(context.Background(), &smithy.GenericAPIError{Code: "ThrottlingException"}); err != nil {
+		t.Fatal(err)
+	}
+	want := RetryStats{Retries: 1, Throttles: 2}
+	if got := r.Stats(); got != want {
+		t.Errorf("Retryer.Stats() = %v, want %v", got, want)
+	}
+}
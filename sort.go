@@ -14,3 +14,17 @@ func SortEntries[E Entry, D EntryData[E]](data D) {
 		return cmp.Compare(a.Name(), b.Name())
 	})
 }
+
+// SortEntriesByAction sorts the entries by action, bytes and name.
+// Entries without action, such as list entries, are sorted as SortEntries does.
+func SortEntriesByAction[E Entry, D EntryData[E]](data D) {
+	slices.SortFunc(data.Entries(), func(a, b E) int {
+		if n := cmp.Compare(a.DataSet()[actionLabel], b.DataSet()[actionLabel]); n != 0 {
+			return n
+		}
+		if n := cmp.Compare(b.DataSet()[storedBytesLabel], a.DataSet()[storedBytesLabel]); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Name(), b.Name())
+	})
+}
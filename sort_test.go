@@ -85,3 +85,35 @@ func TestSortEntries(t *testing.T) {
 		}
 	}
 }
+
+func TestSortEntriesByAction(t *testing.T) {
+	data := &PreviewEntryData{
+		entries: []*PreviewEntry{
+			{
+				entry:  &entry{LogGroupName: "1", StoredBytes: 300},
+				Action: ActionNoop,
+			},
+			{
+				entry:  &entry{LogGroupName: "2", StoredBytes: 100},
+				Action: ActionUpdate,
+			},
+			{
+				entry:  &entry{LogGroupName: "3", StoredBytes: 200},
+				Action: ActionDelete,
+			},
+			{
+				entry:  &entry{LogGroupName: "4", StoredBytes: 200},
+				Action: ActionUpdate,
+			},
+		},
+	}
+	want := []string{"4", "2", "3", "1"}
+	SortEntriesByAction(data)
+	var got []string
+	for _, e := range data.entries {
+		got = append(got, e.LogGroupName)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortEntriesByAction() = %v, want %v", got, want)
+	}
+}
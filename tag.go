@@ -0,0 +1,353 @@
+package llcm
+
+import (
+	"cmp"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+const (
+	// tagKeyPrefix is the prefix of the filter key and the column that refer to a tag, e.g. tag.env.
+	tagKeyPrefix = "tag."
+
+	// tagIdentPrefix is the prefix of the identifier that the tag key is rewritten into,
+	// since the filter accepts only alphanumerics and underscores in identifiers.
+	tagIdentPrefix = "tag_"
+)
+
+// tagIdent returns the identifier of the filter that refers to the tag key.
+func tagIdent(key string) string {
+	return tagIdentPrefix + hex.EncodeToString([]byte(key))
+}
+
+// tagKey returns the tag key referred to by the filter key, either tag.<key> or the identifier rewritten from it.
+func tagKey(s string) (string, bool) {
+	if key, ok := strings.CutPrefix(s, tagKeyPrefix); ok && key != "" {
+		return key, true
+	}
+	if ident, ok := strings.CutPrefix(s, tagIdentPrefix); ok {
+		b, err := hex.DecodeString(ident)
+		if err == nil && len(b) > 0 {
+			return string(b), true
+		}
+	}
+	return "", false
+}
+
+// fetchTags reports whether to list the tags of the log groups. They are needed on request,
+// for the tag columns, for the desired state declared by the tags, or for the filters.
+func (man *Manager) fetchTags() bool {
+	return man.withTags || man.desiredTagKey != "" || len(man.tagColumns) > 0 || man.refersTo(tagKeyPrefix)
+}
+
+// tagHeader returns the header followed by the tag keys selected as the extra columns.
+func tagHeader(header, columns []string) []string {
+	if len(columns) == 0 {
+		return header
+	}
+	h := make([]string, 0, len(header)+len(columns))
+	h = append(h, header...)
+	for _, k := range columns {
+		h = append(h, tagKeyPrefix+k)
+	}
+	return h
+}
+
+// listTags sets the tags of the log group to the entry.
+func (man *Manager) listTags(ctx context.Context, e *entry) error {
+	key := limitKey{accountID: e.client.accountID, region: e.Region, api: apiListTagsForResource}
+	in := &cloudwatchlogs.ListTagsForResourceInput{
+		ResourceArn: &e.Arn,
+	}
+	var out *cloudwatchlogs.ListTagsForResourceOutput
+	err := man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) (err error) {
+		out, err = e.client.ListTagsForResource(ctx, in, opt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list tags: %w", err)
+	}
+	e.Tags = out.Tags
+	return nil
+}
+
+const (
+	// ManagedByTagKey is the tag key written to the log group changed by apply, whose value is always llcm.
+	ManagedByTagKey = "llcm:managed-by"
+
+	// AppliedAtTagKey is the tag key written to the log group changed by apply, whose value is the time in RFC3339.
+	AppliedAtTagKey = "llcm:applied-at"
+
+	// AppliedStateTagKey is the tag key written to the log group changed by apply, whose value is the desired state applied.
+	AppliedStateTagKey = "llcm:desired-state"
+
+	// managedByTagValue is the value of the tag ManagedByTagKey.
+	managedByTagValue = "llcm"
+)
+
+// appliedTags returns the tags written to the log group changed to the desired state at the time.
+func appliedTags(desired DesiredState, t time.Time) map[string]string {
+	return map[string]string{
+		ManagedByTagKey:    managedByTagValue,
+		AppliedAtTagKey:    t.UTC().Format(time.RFC3339),
+		AppliedStateTagKey: desired.String(),
+	}
+}
+
+// tagResource writes the tags to the log group.
+func (man *Manager) tagResource(ctx context.Context, client *Client, arn, region string, tags map[string]string) error {
+	key := limitKey{accountID: client.accountID, region: region, api: apiTagResource}
+	in := &cloudwatchlogs.TagResourceInput{
+		ResourceArn: &arn,
+		Tags:        tags,
+	}
+	err := man.limiter.call(ctx, key, man.retryer, func(opt func(*cloudwatchlogs.Options)) error {
+		_, err := client.TagResource(ctx, in, opt)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to tag resource: %w", err)
+	}
+	return nil
+}
+
+const (
+	// DesiredStateTagKey is the default tag key that declares the desired state of the log group, e.g. llcm:retention=90days.
+	DesiredStateTagKey = "llcm:retention"
+
+	// ProtectTagKey is the tag key that declares whether to protect the log group from deletion, e.g. llcm:protect=true.
+	// It is honored when the desired state is resolved from the default tag key and that tag is missing.
+	ProtectTagKey = "llcm:protect"
+
+	// fromTagPrefix is the prefix of the desired state that means resolving it from the tag of each log group.
+	fromTagPrefix = "from-tag"
+)
+
+var (
+	_ Entry                = (*TagIssue)(nil)
+	_ EntryData[*TagIssue] = (TagIssues)(nil)
+	_ error                = (TagIssues)(nil)
+)
+
+// TotalTagIssuesLabel is the label of the total number of log groups with missing or invalid tags.
+var TotalTagIssuesLabel = "tagIssues"
+
+// tagIssuesHeader is the header of TagIssues.
+var tagIssuesHeader = []string{
+	"AccountID",
+	"Region",
+	"Name",
+	"Key",
+	"Value",
+	"Reason",
+	"Fallback",
+}
+
+// parseFromTag parses the desired state that means resolving it from the tag, e.g. from-tag or from-tag:retention.
+// It reports false if the desired state is not the one.
+func parseFromTag(s string) (string, bool, error) {
+	rest, ok := strings.CutPrefix(s, fromTagPrefix)
+	if !ok {
+		return "", false, nil
+	}
+	if rest == "" {
+		return DesiredStateTagKey, true, nil
+	}
+	key, ok := strings.CutPrefix(rest, ":")
+	if !ok {
+		return "", false, nil
+	}
+	if key == "" {
+		return "", true, fmt.Errorf("empty tag key: %q", s)
+	}
+	return key, true, nil
+}
+
+// desiredStateFromTag returns the desired state declared by the tag of the entry and the tag key used as the rule.
+// Without the tag of the default key, the protect tag is honored instead.
+func (man *Manager) desiredStateFromTag(e *entry) (DesiredState, string, *TagIssue) {
+	key := man.desiredTagKey
+	v, ok := e.Tags[key]
+	if !ok && key == DesiredStateTagKey {
+		if p, ok := e.Tags[ProtectTagKey]; ok {
+			b, err := strconv.ParseBool(p)
+			if err != nil {
+				return DesiredStateNone, "", man.newTagIssue(e, ProtectTagKey, p, err.Error())
+			}
+			if b {
+				return DesiredStateProtected, tagKeyPrefix + ProtectTagKey, nil
+			}
+			return DesiredStateUnprotected, tagKeyPrefix + ProtectTagKey, nil
+		}
+	}
+	if !ok {
+		return DesiredStateNone, "", man.newTagIssue(e, key, "", "missing tag")
+	}
+	d, err := ParseDesiredStateWithRound(v, man.round)
+	if err != nil {
+		return DesiredStateNone, "", man.newTagIssue(e, key, v, err.Error())
+	}
+	return d, tagKeyPrefix + key, nil
+}
+
+// newTagIssue creates a new issue of the tag of the entry with the fallback desired state.
+func (man *Manager) newTagIssue(e *entry, key, value, reason string) *TagIssue {
+	fallback := DesiredStateNone
+	if man.hasDefault {
+		fallback = man.defaultState
+	}
+	return &TagIssue{
+		AccountID:    e.AccountID,
+		Region:       e.Region,
+		LogGroupName: e.LogGroupName,
+		Key:          key,
+		Value:        value,
+		Reason:       reason,
+		Fallback:     fallback,
+	}
+}
+
+// TagIssue represents a log group whose desired state cannot be resolved from its tag.
+type TagIssue struct {
+	AccountID    string       // The account ID that owns the log group.
+	Region       string       // The region that the log group belongs to.
+	LogGroupName string       // The name of the log group.
+	Key          string       // The tag key.
+	Value        string       // The tag value, empty if missing.
+	Reason       string       // The reason why the desired state cannot be resolved.
+	Fallback     DesiredState // The default desired state used instead, or none if skipped.
+}
+
+// Name returns the name of the log group.
+func (t *TagIssue) Name() string {
+	return t.LogGroupName
+}
+
+// account returns the account ID that owns the log group.
+func (t *TagIssue) account() string {
+	return t.AccountID
+}
+
+// DataSet returns map for plotting the chart.
+func (t *TagIssue) DataSet() map[string]int64 {
+	return map[string]int64{}
+}
+
+// toInput returns the input of the tag issue for rendering.
+func (t *TagIssue) toInput() []any {
+	return []any{
+		t.AccountID,
+		t.Region,
+		t.LogGroupName,
+		t.Key,
+		t.Value,
+		t.Reason,
+		t.Fallback.String(),
+	}
+}
+
+// toTSV returns the tab-separated values of the tag issue for rendering.
+func (t *TagIssue) toTSV() []string {
+	return []string{
+		t.AccountID,
+		t.Region,
+		t.LogGroupName,
+		t.Key,
+		t.Value,
+		t.Reason,
+		t.Fallback.String(),
+	}
+}
+
+// TagIssues represents the log groups whose desired state cannot be resolved from their tags.
+// It can be rendered as a summary table apart from the results.
+// It is also an error so that the stream of the results can yield it at last, which is not a failure of the log groups.
+type TagIssues []*TagIssue
+
+// Error returns the number of the log groups whose tags do not declare the desired state.
+func (t TagIssues) Error() string {
+	return fmt.Sprintf("%d log groups with tags not declaring the desired state", len(t))
+}
+
+// Header returns the header of the TagIssues.
+func (t TagIssues) Header() []string {
+	return tagIssuesHeader
+}
+
+// Entries returns the entries of the TagIssues.
+func (t TagIssues) Entries() []*TagIssue {
+	if len(t) == 0 {
+		return nil
+	}
+	return t
+}
+
+// Total returns the total of the TagIssues.
+func (t TagIssues) Total() map[string]int64 {
+	return map[string]int64{
+		TotalTagIssuesLabel: int64(len(t)),
+	}
+}
+
+// TotalByAccount returns the total of the TagIssues for each account.
+func (t TagIssues) TotalByAccount() map[string]map[string]int64 {
+	m := make(map[string]map[string]int64)
+	for _, ti := range t {
+		if _, ok := m[ti.AccountID]; !ok {
+			m[ti.AccountID] = map[string]int64{
+				TotalTagIssuesLabel: 0,
+			}
+		}
+		m[ti.AccountID][TotalTagIssuesLabel]++
+	}
+	return m
+}
+
+// Chart does nothing because the tag issues are not plotted.
+func (t TagIssues) Chart() error {
+	return nil
+}
+
+// sort sorts the tag issues by account, region and log group name.
+func (t TagIssues) sort() {
+	slices.SortFunc(t, func(a, b *TagIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.AccountID, b.AccountID),
+			cmp.Compare(a.Region, b.Region),
+			cmp.Compare(a.LogGroupName, b.LogGroupName),
+		)
+	})
+}
+
+// tagIssueSet represents the tag issues collected in a run.
+type tagIssueSet struct {
+	mu     sync.Mutex
+	issues TagIssues
+}
+
+// add adds the issue if it is not nil.
+func (s *tagIssueSet) add(issue *TagIssue) {
+	if issue == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.issues = append(s.issues, issue)
+}
+
+// sorted returns the issues collected so far, sorted by account, region and log group name.
+func (s *tagIssueSet) sorted() TagIssues {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := slices.Clone(s.issues)
+	t.sort()
+	return t
+}
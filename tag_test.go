@@ -0,0 +1,477 @@
+package llcm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestTagKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "filter key",
+			s:      "tag.env",
+			want:   "env",
+			wantOk: true,
+		},
+		{
+			name:   "filter key with colons",
+			s:      "tag.aws:cloudformation:stack-name",
+			want:   "aws:cloudformation:stack-name",
+			wantOk: true,
+		},
+		{
+			name:   "identifier",
+			s:      tagIdent("env"),
+			want:   "env",
+			wantOk: true,
+		},
+		{
+			name:   "empty key",
+			s:      "tag.",
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "invalid identifier",
+			s:      "tag_xyz",
+			want:   "",
+			wantOk: false,
+		},
+		{
+			name:   "other key",
+			s:      "name",
+			want:   "",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tagKey(tt.s)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("tagKey() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestManager_fetchTags(t *testing.T) {
+	policy, err := ParsePolicy([]byte("rules:\n  - name: prod\n    filter: tag.env == \"prod\"\n    desired: 1year\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		man  *Manager
+		want bool
+	}{
+		{
+			name: "requested",
+			man:  &Manager{withTags: true},
+			want: true,
+		},
+		{
+			name: "columns",
+			man:  &Manager{tagColumns: []string{"env"}},
+			want: true,
+		},
+		{
+			name: "from tag",
+			man:  &Manager{desiredTagKey: DesiredStateTagKey},
+			want: true,
+		},
+		{
+			name: "filter",
+			man:  &Manager{filterRaw: `tag.env == "prod"`},
+			want: true,
+		},
+		{
+			name: "policy rule",
+			man:  &Manager{policy: policy},
+			want: true,
+		},
+		{
+			name: "not needed",
+			man:  &Manager{filterRaw: `name == "tag.env"`},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.man.fetchTags(); got != tt.want {
+				t.Errorf("Manager.fetchTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagHeader(t *testing.T) {
+	header := []string{"Name", "Region"}
+	got := tagHeader(header, []string{"env", "owner"})
+	if diff := cmp.Diff([]string{"Name", "Region", "tag.env", "tag.owner"}, got); diff != "" {
+		t.Errorf("tagHeader() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Name", "Region"}, header); diff != "" {
+		t.Errorf("tagHeader() modified the header (-want +got):\n%s", diff)
+	}
+}
+
+func TestEntry_tagValues(t *testing.T) {
+	e := &ListEntry{
+		entry: &entry{
+			LogGroupName: "group0",
+			Tags:         map[string]string{"env": "prod"},
+			tagColumns:   []string{"env", "owner"},
+		},
+	}
+	got := e.toTSV()
+	if diff := cmp.Diff([]string{"prod", ""}, got[len(got)-2:]); diff != "" {
+		t.Errorf("ListEntry.toTSV() mismatch (-want +got):\n%s", diff)
+	}
+	if n := len(e.toInput()); n != len(got) {
+		t.Errorf("ListEntry.toInput() length = %v, want %v", n, len(got))
+	}
+}
+
+func TestManager_listTags(t *testing.T) {
+	const arn = "arn:aws:logs:us-east-1:123456789012:log-group:group0"
+	tests := []struct {
+		name    string
+		client  *Client
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "tagged",
+			client: newMockClient(&mockClient{
+				ListTagsForResourceFunc: func(_ context.Context, in *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+					if aws.ToString(in.ResourceArn) != arn {
+						return nil, errors.New("unexpected arn")
+					}
+					return &cloudwatchlogs.ListTagsForResourceOutput{Tags: map[string]string{"env": "prod"}}, nil
+				},
+			}),
+			want:    map[string]string{"env": "prod"},
+			wantErr: false,
+		},
+		{
+			name: "api error",
+			client: newMockClient(&mockClient{
+				ListTagsForResourceFunc: func(_ context.Context, _ *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+					return nil, errors.New("api error")
+				},
+			}),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{limiter: newLimiter()}
+			e := &entry{Region: "us-east-1", Arn: arn, client: tt.client}
+			err := man.listTags(context.Background(), e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Manager.listTags() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, e.Tags); diff != "" {
+				t.Errorf("Manager.listTags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseFromTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantOk  bool
+		wantErr bool
+	}{
+		{
+			name:    "default key",
+			s:       "from-tag",
+			want:    DesiredStateTagKey,
+			wantOk:  true,
+			wantErr: false,
+		},
+		{
+			name:    "custom key",
+			s:       "from-tag:retention",
+			want:    "retention",
+			wantOk:  true,
+			wantErr: false,
+		},
+		{
+			name:    "empty key",
+			s:       "from-tag:",
+			want:    "",
+			wantOk:  true,
+			wantErr: true,
+		},
+		{
+			name:    "desired state",
+			s:       "90days",
+			want:    "",
+			wantOk:  false,
+			wantErr: false,
+		},
+		{
+			name:    "similar prefix",
+			s:       "from-tags",
+			want:    "",
+			wantOk:  false,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := parseFromTag(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseFromTag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseFromTag() got = %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("parseFromTag() ok = %v, want %v", ok, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestManager_resolve_fromTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		defaultSet bool
+		tags       map[string]string
+		want       DesiredState
+		wantRule   string
+		wantOk     bool
+		wantIssue  string
+	}{
+		{
+			name:     "retention tag",
+			key:      DesiredStateTagKey,
+			tags:     map[string]string{DesiredStateTagKey: "90days"},
+			want:     DesiredStateThreeMonths,
+			wantRule: "tag.llcm:retention",
+			wantOk:   true,
+		},
+		{
+			name:     "custom key",
+			key:      "retention",
+			tags:     map[string]string{"retention": "1week"},
+			want:     DesiredStateOneWeek,
+			wantRule: "tag.retention",
+			wantOk:   true,
+		},
+		{
+			name:     "protect tag",
+			key:      DesiredStateTagKey,
+			tags:     map[string]string{ProtectTagKey: "true"},
+			want:     DesiredStateProtected,
+			wantRule: "tag.llcm:protect",
+			wantOk:   true,
+		},
+		{
+			name:     "retention tag over protect tag",
+			key:      DesiredStateTagKey,
+			tags:     map[string]string{DesiredStateTagKey: "1day", ProtectTagKey: "false"},
+			want:     DesiredStateOneDay,
+			wantRule: "tag.llcm:retention",
+			wantOk:   true,
+		},
+		{
+			name:      "protect tag with custom key",
+			key:       "retention",
+			tags:      map[string]string{ProtectTagKey: "true"},
+			want:      DesiredStateNone,
+			wantOk:    false,
+			wantIssue: "missing tag",
+		},
+		{
+			name:      "missing tag",
+			key:       DesiredStateTagKey,
+			tags:      nil,
+			want:      DesiredStateNone,
+			wantOk:    false,
+			wantIssue: "missing tag",
+		},
+		{
+			name:       "missing tag with default",
+			key:        DesiredStateTagKey,
+			defaultSet: true,
+			tags:       nil,
+			want:       DesiredStateOneYear,
+			wantRule:   "default",
+			wantOk:     true,
+			wantIssue:  "missing tag",
+		},
+		{
+			name:       "invalid tag with default",
+			key:        DesiredStateTagKey,
+			defaultSet: true,
+			tags:       map[string]string{DesiredStateTagKey: "forever"},
+			want:       DesiredStateOneYear,
+			wantRule:   "default",
+			wantOk:     true,
+			wantIssue:  `unsupported desired state: "forever"`,
+		},
+		{
+			name:      "invalid protect tag",
+			key:       DesiredStateTagKey,
+			tags:      map[string]string{ProtectTagKey: "yes"},
+			want:      DesiredStateNone,
+			wantOk:    false,
+			wantIssue: `strconv.ParseBool: parsing "yes": invalid syntax`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			man := &Manager{desiredTagKey: tt.key}
+			if tt.defaultSet {
+				if err := man.SetDefaultState(DesiredStateOneYear.String()); err != nil {
+					t.Fatal(err)
+				}
+			}
+			e := &entry{AccountID: "123456789012", Region: "us-east-1", LogGroupName: "group0", Tags: tt.tags}
+			got, rule, ok, err := man.resolve(e)
+			if err != nil {
+				t.Errorf("Manager.resolve() error = %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Manager.resolve() got = %v, want %v", got, tt.want)
+			}
+			if rule != tt.wantRule {
+				t.Errorf("Manager.resolve() rule = %v, want %v", rule, tt.wantRule)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("Manager.resolve() ok = %v, want %v", ok, tt.wantOk)
+			}
+			issue := man.tagIssue(e)
+			if tt.wantIssue == "" {
+				if issue != nil {
+					t.Errorf("Manager.tagIssue() = %v, want nil", issue)
+				}
+				return
+			}
+			if issue == nil || issue.Reason != tt.wantIssue {
+				t.Errorf("Manager.tagIssue() = %v, want reason %q", issue, tt.wantIssue)
+				return
+			}
+			if tt.defaultSet && issue.Fallback != DesiredStateOneYear {
+				t.Errorf("Manager.tagIssue() fallback = %v, want %v", issue.Fallback, DesiredStateOneYear)
+			}
+		})
+	}
+}
+
+func TestTagIssues(t *testing.T) {
+	issues := TagIssues{
+		{AccountID: "210987654321", Region: "us-east-1", LogGroupName: "group0"},
+		{AccountID: "123456789012", Region: "us-east-1", LogGroupName: "group1"},
+		{AccountID: "123456789012", Region: "ap-northeast-1", LogGroupName: "group2"},
+	}
+	issues.sort()
+	var got []string
+	for _, e := range issues.Entries() {
+		got = append(got, e.Name())
+	}
+	if diff := cmp.Diff([]string{"group2", "group1", "group0"}, got); diff != "" {
+		t.Errorf("TagIssues.sort() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int64{TotalTagIssuesLabel: 3}, issues.Total()); diff != "" {
+		t.Errorf("TagIssues.Total() mismatch (-want +got):\n%s", diff)
+	}
+	want := map[string]map[string]int64{
+		"123456789012": {TotalTagIssuesLabel: 2},
+		"210987654321": {TotalTagIssuesLabel: 1},
+	}
+	if diff := cmp.Diff(want, issues.TotalByAccount()); diff != "" {
+		t.Errorf("TagIssues.TotalByAccount() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestManager_Preview_tagIssues(t *testing.T) {
+	client := newMockClient(&mockClient{
+		DescribeLogGroupsFunc: func(_ context.Context, _ *cloudwatchlogs.DescribeLogGroupsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+			out := &cloudwatchlogs.DescribeLogGroupsOutput{
+				LogGroups: []types.LogGroup{
+					{LogGroupName: aws.String("group0"), Arn: aws.String("arn0"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(90)},
+					{LogGroupName: aws.String("group1"), Arn: aws.String("arn1"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(90)},
+					{LogGroupName: aws.String("group2"), Arn: aws.String("arn2"), CreationTime: aws.Int64(mustUnixMilli("2025-01-01T00:00:00Z")), StoredBytes: aws.Int64(90)},
+				},
+			}
+			return out, nil
+		},
+		ListTagsForResourceFunc: func(_ context.Context, in *cloudwatchlogs.ListTagsForResourceInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.ListTagsForResourceOutput, error) {
+			if aws.ToString(in.ResourceArn) == "arn0" {
+				return &cloudwatchlogs.ListTagsForResourceOutput{Tags: map[string]string{DesiredStateTagKey: "1day"}}, nil
+			}
+			return &cloudwatchlogs.ListTagsForResourceOutput{}, nil
+		},
+	})
+	resultExpr, err := parseFilter(`name != "group2"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	man := &Manager{
+		clock:         nowFunc,
+		client:        client,
+		regions:       []string{"us-east-1"},
+		desiredTagKey: DesiredStateTagKey,
+		resultExpr:    resultExpr,
+		sem:           semaphore.NewWeighted(10),
+	}
+	want := []string{"group1"}
+	names := func(issues TagIssues) []string {
+		var s []string
+		for _, issue := range issues {
+			s = append(s, issue.LogGroupName)
+		}
+		return s
+	}
+	for range 2 {
+		data, err := man.Preview(context.Background())
+		if err != nil {
+			t.Fatalf("Manager.Preview() error = %v", err)
+		}
+		if diff := cmp.Diff(want, names(data.TagIssues())); diff != "" {
+			t.Errorf("PreviewEntryData.TagIssues() mismatch (-want +got):\n%s", diff)
+		}
+		if n := len(data.Entries()); n != 1 {
+			t.Errorf("Manager.Preview() entries = %d, want 1", n)
+		}
+	}
+	var issues TagIssues
+	for e, err := range man.PreviewAll(context.Background()) {
+		if err != nil {
+			if !errors.As(err, &issues) {
+				t.Fatalf("Manager.PreviewAll() error = %v", err)
+			}
+			continue
+		}
+		if e.LogGroupName != "group0" {
+			t.Errorf("Manager.PreviewAll() yielded %q, want group0", e.LogGroupName)
+		}
+	}
+	if diff := cmp.Diff(want, names(issues)); diff != "" {
+		t.Errorf("Manager.PreviewAll() tag issues mismatch (-want +got):\n%s", diff)
+	}
+}